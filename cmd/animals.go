@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/pkg/errors"
+)
+
+const (
+	animalsUsage = "usage: animals export [-o file] | import -owner email [-f file] [-format csv|jsonl] [-photos dir] [-dry-run]"
+	// exportPageSize is how many listings are loaded at a time.
+	exportPageSize = 500
+)
+
+// runAnimals exports the listings as JSON Lines of the API representation of
+// an animal, or imports them from such a file or a CSV.
+func runAnimals(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New(animalsUsage)
+	}
+	switch args[0] {
+	case "export":
+		return exportAnimals(app, args[1:])
+	case "import":
+		return importAnimals(app, args[1:])
+	default:
+		return errors.New(animalsUsage)
+	}
+}
+
+func exportAnimals(app *app, args []string) error {
+	flags := flag.NewFlagSet("animals export", flag.ContinueOnError)
+	output := flags.String("o", "", "file to write, standard output when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+
+	store := animals.NewAnimalStore(app.db, app.config.ReshowPolicy)
+	var afterID uint
+	count := 0
+	for {
+		page, err := store.GetAnimalsAfter(afterID, exportPageSize)
+		if err != nil {
+			return err
+		}
+		for _, a := range page {
+			if err := encoder.Encode(models.ToAnimalJSON(a)); err != nil {
+				return err
+			}
+		}
+		count += len(page)
+		if len(page) < exportPageSize {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d animals\n", count)
+	return nil
+}
+
+// importAnimals adds the listings of a CSV or JSON Lines file to the owner.
+// Photos are URLs or paths relative to the photos directory, which defaults
+// to the directory of the file.
+func importAnimals(app *app, args []string) error {
+	flags := flag.NewFlagSet("animals import", flag.ContinueOnError)
+	input := flags.String("f", "", "file to read, standard input when empty")
+	format := flags.String("format", "", "csv or jsonl, by the file extension when empty")
+	photosDir := flags.String("photos", "", "directory that photo paths are relative to")
+	owner := flags.String("owner", "", "email of the user the listings belong to")
+	dryRun := flags.Bool("dry-run", false, "only validate the rows")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *owner == "" {
+		return errors.New(animalsUsage)
+	}
+	if *format == "" {
+		var err error
+		if *format, err = imports.FormatOf("", *input); err != nil {
+			return errors.Wrap(err, "set -format")
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if *input != "" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+		if *photosDir == "" {
+			*photosDir = filepath.Dir(*input)
+		}
+	}
+	var files fs.FS
+	if *photosDir != "" {
+		files = os.DirFS(*photosDir)
+	}
+
+	user, err := users.NewUserStore(app.db).GetByEmail(*owner)
+	if err != nil {
+		return errors.Wrap(err, "unknown owner")
+	}
+	blobStore, _, err := newBlobStore(app.config)
+	if err != nil {
+		return err
+	}
+	importService := services.NewImportService(animals.NewAnimalStore(app.db, app.config.ReshowPolicy),
+		photos.NewPhotoService(blobStore), &http.Client{Timeout: constants.ImportFetchTimeout})
+
+	report, err := importService.ImportAnimals(user.ID, in, services.ImportOptions{Format: *format, DryRun: *dryRun, Files: files})
+	if err != nil {
+		return err
+	}
+	for _, e := range report.Errors {
+		fmt.Fprintf(os.Stderr, "row %d: %s\n", e.Row, e.Error)
+	}
+	for _, w := range report.Warnings {
+		fmt.Fprintf(os.Stderr, "row %d: animal %d shares photos with %d other listings\n", w.Row, w.AnimalID, len(w.Duplicates))
+	}
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "%d of %d rows are valid\n", report.Valid, report.Rows)
+	} else {
+		fmt.Fprintf(os.Stderr, "imported %d of %d rows\n", report.Imported, report.Rows)
+	}
+	if len(report.Errors) > 0 {
+		return errors.Errorf("%d rows failed", len(report.Errors))
+	}
+	return nil
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/feed"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
@@ -22,6 +26,30 @@ const (
 	//
 	ginPortEnv  = "GIN_PORT"
 	environment = "ENV"
+	// swipe feed re-show policy
+	feedPassedHideForEnv     = "FEED_PASSED_HIDE_FOR"
+	feedLikedHideForEnv      = "FEED_LIKED_HIDE_FOR"
+	feedResurfaceOnChangeEnv = "FEED_RESURFACE_ON_CHANGE"
+	// saved search digests
+	publicURLEnv           = "PUBLIC_URL"
+	savedSearchIntervalEnv = "SAVED_SEARCH_INTERVAL"
+	smtpHostEnv            = "SMTP_HOST"
+	smtpPortEnv            = "SMTP_PORT"
+	smtpUsernameEnv        = "SMTP_USERNAME"
+	smtpPasswordEnv        = "SMTP_PASSWORD"
+	smtpFromEnv            = "SMTP_FROM"
+	// file storage
+	storageBackendEnv  = "STORAGE_BACKEND"
+	s3BucketEnv        = "S3_BUCKET"
+	s3EndpointEnv      = "S3_ENDPOINT"
+	localStorageDirEnv = "LOCAL_STORAGE_DIR"
+)
+
+// Storage backends.
+const (
+	storageS3     = "s3"
+	storageLocal  = "local"
+	storageMemory = "memory"
 )
 
 const (
@@ -30,9 +58,33 @@ const (
 )
 
 type config struct {
-	LogLevel zerolog.Level
-	Database *dbConfig
-	GinPort  string
+	LogLevel     zerolog.Level
+	Database     *dbConfig
+	GinPort      string
+	ReshowPolicy feed.ReshowPolicy
+	PublicURL    string
+	// SavedSearchInterval is how often each saved search sends a digest.
+	SavedSearchInterval time.Duration
+	// SMTP is nil when email delivery is not configured.
+	SMTP    *smtpConfig
+	Storage *storageConfig
+}
+
+type storageConfig struct {
+	Backend string
+	// S3 bucket and, for S3 compatible stores, their endpoint.
+	Bucket   string
+	Endpoint string
+	// LocalDir is where the local backend keeps files.
+	LocalDir string
+}
+
+type smtpConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
 }
 
 type dbConfig struct {
@@ -57,6 +109,29 @@ func loadConfig() (*config, error) {
 		return nil, errors.Wrap(err, "error parsing log level")
 	}
 
+	reshowPolicy, err := loadReshowPolicy()
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing feed re-show policy")
+	}
+
+	savedSearchInterval := constants.SavedSearchDigestInterval
+	if v := viper.GetString(savedSearchIntervalEnv); v != "" {
+		if savedSearchInterval, err = time.ParseDuration(v); err != nil {
+			return nil, errors.Wrap(err, "error parsing "+savedSearchIntervalEnv)
+		}
+	}
+
+	storage, err := loadStorageConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	smtp := loadSMTPConfig()
+	publicURL, err := loadPublicURL(smtp)
+	if err != nil {
+		return nil, err
+	}
+
 	if isDevEnv() {
 		return &config{
 			LogLevel: logLevel,
@@ -64,7 +139,13 @@ func loadConfig() (*config, error) {
 				ReadURL:  viper.GetString(dbReadURL),
 				WriteURL: viper.GetString(dbWriteURL),
 			},
-			GinPort: ":" + viper.GetString(ginPortEnv),
+			GinPort:      ":" + viper.GetString(ginPortEnv),
+			ReshowPolicy: reshowPolicy,
+			PublicURL:    publicURL,
+
+			SavedSearchInterval: savedSearchInterval,
+			SMTP:                smtp,
+			Storage:             storage,
 		}, nil
 	}
 	if isProdEnv() {
@@ -74,12 +155,102 @@ func loadConfig() (*config, error) {
 				ReadURL:  viper.GetString(dbReadURLrender),
 				WriteURL: viper.GetString(dbWriteURLrender),
 			},
-			GinPort: ":" + viper.GetString(ginPortEnv),
+			GinPort:      ":" + viper.GetString(ginPortEnv),
+			ReshowPolicy: reshowPolicy,
+			PublicURL:    publicURL,
+
+			SavedSearchInterval: savedSearchInterval,
+			SMTP:                smtp,
+			Storage:             storage,
 		}, nil
 	}
 	return nil, errors.Wrap(err, "error reading config")
 }
 
+// loadReshowPolicy overrides the default feed re-show policy with the
+// variables that are set.
+func loadReshowPolicy() (feed.ReshowPolicy, error) {
+	policy := feed.DefaultReshowPolicy
+	var err error
+
+	if v := viper.GetString(feedPassedHideForEnv); v != "" {
+		if policy.PassedHideFor, err = feed.ParseHideDuration(v); err != nil {
+			return policy, errors.Wrap(err, feedPassedHideForEnv)
+		}
+	}
+	if v := viper.GetString(feedLikedHideForEnv); v != "" {
+		if policy.LikedHideFor, err = feed.ParseHideDuration(v); err != nil {
+			return policy, errors.Wrap(err, feedLikedHideForEnv)
+		}
+	}
+	if viper.IsSet(feedResurfaceOnChangeEnv) {
+		policy.ResurfaceOnChange = viper.GetBool(feedResurfaceOnChangeEnv)
+	}
+
+	return policy, nil
+}
+
+func loadSMTPConfig() *smtpConfig {
+	host := viper.GetString(smtpHostEnv)
+	if host == "" {
+		return nil
+	}
+	port := viper.GetString(smtpPortEnv)
+	if port == "" {
+		port = "587"
+	}
+	return &smtpConfig{
+		Host:     host,
+		Port:     port,
+		Username: viper.GetString(smtpUsernameEnv),
+		Password: viper.GetString(smtpPasswordEnv),
+		From:     viper.GetString(smtpFromEnv),
+	}
+}
+
+// loadPublicURL checks that the URL the API is served at is absolute, as
+// emails link to it. It is required once emails are sent.
+func loadPublicURL(smtp *smtpConfig) (string, error) {
+	publicURL := viper.GetString(publicURLEnv)
+	if publicURL == "" {
+		if smtp != nil {
+			return "", errors.Errorf("%s is required when %s is set, emails link to it", publicURLEnv, smtpHostEnv)
+		}
+		return "", nil
+	}
+	u, err := url.Parse(publicURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", errors.Errorf("%s must be an absolute http or https URL, got %q", publicURLEnv, publicURL)
+	}
+	return publicURL, nil
+}
+
+// loadStorageConfig defaults to the S3 bucket the service has always used.
+func loadStorageConfig() (*storageConfig, error) {
+	config := &storageConfig{
+		Backend:  strings.ToLower(viper.GetString(storageBackendEnv)),
+		Bucket:   viper.GetString(s3BucketEnv),
+		Endpoint: viper.GetString(s3EndpointEnv),
+		LocalDir: viper.GetString(localStorageDirEnv),
+	}
+	if config.Backend == "" {
+		config.Backend = storageS3
+	}
+	if config.Bucket == "" {
+		config.Bucket = "findyourpet-kach"
+	}
+	if config.LocalDir == "" {
+		config.LocalDir = "uploads"
+	}
+
+	switch config.Backend {
+	case storageS3, storageLocal, storageMemory:
+		return config, nil
+	default:
+		return nil, errors.Errorf("unknown %s %q", storageBackendEnv, config.Backend)
+	}
+}
+
 func isDevEnv() bool {
 	return viper.GetString(environment) == dev
 }
@@ -2,60 +2,90 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
-	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/initializers"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/logger"
-	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
-	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
-
-	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
-	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/awsS3"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/db"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 	"gorm.io/gorm"
 )
 
 const appName = "findyourpet-backend"
 
-var gormDB *gorm.DB
-var configuration *config
-
-func init() {
-	// prepare config
-	var err error
-	configuration, err = loadConfig()
-	if err != nil {
-		log.Fatal().Err(err).Msg("unable to prepare config")
-	}
-	log.Info().Str("ENV", viper.GetString(environment)).Err(err).Msg("config is ready")
-	ctx := context.Background()
-
-	initLogger(ctx, configuration.LogLevel)
-	gormDB, err = connectDB(ctx, configuration.Database)
+type command struct {
+	name  string
+	usage string
+	run   func(app *app, args []string) error
+}
 
-	if err != nil {
-		log.Ctx(ctx).Fatal().Err(err).Msg("unable to connect to database")
-	}
+// commands of the binary, the first one runs when none is given.
+var commands = []command{
+	{"serve", "serve", runServe},
+	{"migrate", "migrate up | down [steps] | status", runMigrate},
+	{"seed", "seed [-users n] [-animals n] [-seed n] [-images] [-force]", runSeed},
+	{"user", "user create-admin | reset-password -email address [-password password]", runUser},
+	{"animals", "animals export [-o file] | import -owner email [-f file] [-format csv|jsonl] [-photos dir] [-dry-run]", runAnimals},
+	{"media", "media gc [-prefix prefix] [-grace duration] [-dry-run]", runMedia},
+	{"rank", "rank eval [-holdout share] [-min-swipes n] [-k n]", runRank},
+}
 
-	initializers.SyncDatabase(gormDB)
+// app is what every command shares: the configuration and the database.
+type app struct {
+	config *config
+	db     *gorm.DB
 }
 
 func main() {
+	name, args := commands[0].name, os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
 
-	userStore := users.NewUserStore(gormDB)
-	animalStore := animals.NewAnimalStore(gormDB)
-	s3Service := awsS3.NewS3Service("findyourpet-kach")
-	animalService := services.NewAnimalService(animalStore, s3Service)
-	router := initializers.NewRouter(gormDB, userStore, animalStore, animalService)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		app, err := setup()
+		if err != nil {
+			log.Fatal().Err(err).Msg("unable to start")
+		}
+		if err := cmd.run(app, args); err != nil {
+			log.Fatal().Err(err).Msgf("%s failed", name)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q, usage:\n", name)
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", appName, cmd.usage)
+	}
+	os.Exit(2)
+}
 
-	ginEngine := gin.Default()
-	router.SetupAPIs(ginEngine)
+// setup loads the config, then sets up the logger and connects to the
+// database.
+func setup() (*app, error) {
+	configuration, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare config: %w", err)
+	}
+	log.Info().Str("ENV", viper.GetString(environment)).Msg("config is ready")
+	ctx := context.Background()
 
-	ginEngine.Run(configuration.GinPort)
+	initLogger(ctx, configuration.LogLevel)
+	gormDB, err := connectDB(ctx, configuration.Database)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+	return &app{config: configuration, db: gormDB}, nil
 }
 
 func initLogger(ctx context.Context, logLevel zerolog.Level) {
@@ -63,6 +93,25 @@ func initLogger(ctx context.Context, logLevel zerolog.Level) {
 	log.Ctx(ctx).Info().Msg("logger initialized")
 }
 
+// newBlobStore sets up the configured storage backend. The local store is
+// also returned, as its files are served by the API.
+func newBlobStore(config *config) (storage.BlobStoreI, *storage.LocalStore, error) {
+	switch config.Storage.Backend {
+	case storageLocal:
+		baseURL := config.PublicURL
+		if baseURL == "" {
+			baseURL = "http://localhost" + config.GinPort
+		}
+		files, err := storage.NewLocalStore(config.Storage.LocalDir, strings.TrimSuffix(baseURL, "/")+constants.FilesPath)
+		return files, files, err
+	case storageMemory:
+		log.Warn().Msg("files are kept in memory and lost on restart")
+		return storage.NewMemoryStore("memory://"), nil, nil
+	default:
+		return awsS3.NewS3Store(config.Storage.Bucket, config.Storage.Endpoint), nil, nil
+	}
+}
+
 func connectDB(ctx context.Context, dbConfig *dbConfig) (*gorm.DB, error) {
 	// connect to database
 	gormDB, err := db.Connect(ctx, dbConfig.ReadURL, dbConfig.WriteURL, 3, time.Second*2)
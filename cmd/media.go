@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/media"
+	"github.com/pkg/errors"
+)
+
+const mediaUsage = "usage: media gc [-prefix prefix] [-grace duration] [-dry-run]"
+
+// runMedia maintains the files of the configured storage backend.
+func runMedia(app *app, args []string) error {
+	if len(args) == 0 || args[0] != "gc" {
+		return errors.New(mediaUsage)
+	}
+	return collectMedia(app, args[1:])
+}
+
+// collectMedia deletes stored photos that the database no longer refers to,
+// such as the files of failed uploads and of deleted photos.
+//
+// Every object of the blob store is compared with the keys of images, photos
+// and their variants and of message attachments. Orphans stored within the
+// grace period are kept, as their upload may still be in progress. With
+// -dry-run the orphans are only reported.
+func collectMedia(app *app, args []string) error {
+	flags := flag.NewFlagSet("media gc", flag.ContinueOnError)
+	prefix := flags.String("prefix", "", "only look at keys with this prefix")
+	grace := flags.Duration("grace", 24*time.Hour, "keep orphans stored more recently than this")
+	dryRun := flags.Bool("dry-run", false, "report orphans without deleting them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Keys of a schema this binary does not know would be taken for orphans.
+	if err := checkSchema(app); err != nil {
+		return err
+	}
+	blobs, _, err := newBlobStore(app.config)
+	if err != nil {
+		return errors.Wrap(err, "unable to set up file storage")
+	}
+
+	gc := services.NewMediaGCService(media.NewMediaStore(app.db), blobs)
+	report, err := gc.Collect(time.Now(), services.MediaGCOptions{Prefix: *prefix, GracePeriod: *grace, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+	printMediaReport(report)
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d objects could not be deleted", len(report.Failed))
+	}
+	return nil
+}
+
+func printMediaReport(report services.MediaGCReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "orphan\tsize\tstored")
+	for _, object := range report.Orphans {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", object.Key, object.Size, object.ModTime.Format(time.RFC3339))
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Printf("scanned:  %d objects, %d bytes\n", report.Scanned, report.ScannedBytes)
+	fmt.Printf("orphans:  %d objects, %d bytes\n", len(report.Orphans), report.OrphanBytes)
+	fmt.Printf("recent:   %d objects within the grace period\n", report.Recent)
+	if report.DryRun {
+		fmt.Println("deleted:  none, dry run")
+	} else {
+		fmt.Printf("deleted:  %d objects, %d bytes\n", report.Deleted, report.DeletedBytes)
+	}
+	if len(report.Failed) > 0 {
+		fmt.Printf("failed:   %d objects\n", len(report.Failed))
+	}
+	fmt.Printf("took:     %s\n", report.FinishedAt.Sub(report.StartedAt).Round(time.Millisecond))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/migrations"
+	"github.com/pkg/errors"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate applies or rolls back the schema migrations, or lists them.
+func runMigrate(app *app, args []string) error {
+	migrator, err := migrations.New(app.db)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("applied %04d %s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errors.New(migrateUsage)
+			}
+		}
+		rolledBack, err := migrator.Down(steps)
+		for _, m := range rolledBack {
+			fmt.Printf("rolled back %04d %s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "version\tname\tapplied")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, applied)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// checkSchema fails unless every migration of the binary is applied and the
+// database knows of no newer one.
+func checkSchema(app *app) error {
+	migrator, err := migrations.New(app.db)
+	if err == nil {
+		err = migrator.Check()
+	}
+	if err != nil {
+		return errors.Wrap(err, "database schema is not current, run `migrate up`")
+	}
+	return nil
+}
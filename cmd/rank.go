@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const rankUsage = "usage: rank eval [-holdout share] [-min-swipes n] [-k n]"
+
+// runRank works on the ranking of the swipe feed.
+func runRank(app *app, args []string) error {
+	if len(args) == 0 || args[0] != "eval" {
+		return errors.New(rankUsage)
+	}
+	return evaluateRanking(app, args[1:])
+}
+
+// evaluateRanking replays historical swipes to compare feed ranking
+// strategies.
+//
+// For every user with enough swipes the history is split in time: preferences
+// are learned from the earlier swipes and each strategy ranks the animals the
+// user swiped on afterwards. A good strategy places later likes ahead of later
+// passes.
+func evaluateRanking(app *app, args []string) error {
+	flags := flag.NewFlagSet("rank eval", flag.ContinueOnError)
+	holdout := flags.Float64("holdout", 0.2, "share of each user's latest swipes to rank")
+	minSwipes := flags.Int("min-swipes", 10, "skip users with fewer swipes")
+	k := flags.Int("k", 10, "cutoff for precision@k and NDCG@k")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	sessions, err := loadSessions(app.db, *holdout, *minSwipes)
+	if err != nil {
+		return errors.Wrap(err, "unable to load swipe history")
+	}
+
+	strategies := ranking.Strategies()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "strategy\tsessions\tAUC\tP@%d\tNDCG@%d\n", *k, *k)
+	for _, name := range names {
+		m := ranking.Evaluate(strategies[name], sessions, *k)
+		fmt.Fprintf(w, "%s\t%d\t%.4f\t%.4f\t%.4f\n", name, m.Sessions, m.AUC, m.PrecisionAt, m.NDCGAt)
+	}
+	return w.Flush()
+}
+
+type swipe struct {
+	UserID    uuid.UUID
+	AnimalID  uint
+	Liked     bool
+	CreatedAt time.Time
+}
+
+// loadSessions builds one evaluation session per user from the swipe event
+// log, ignoring swipes that were undone.
+func loadSessions(gormDB *gorm.DB, holdout float64, minSwipes int) ([]ranking.Session, error) {
+	var swipes []swipe
+	err := gormDB.
+		Model(&models.SwipeEvent{}).
+		Select("user_id, animal_id, action = ? AS liked, created_at", models.SwipeActionLike).
+		Where("action IN ?", []string{models.SwipeActionLike, models.SwipeActionPass}).
+		Where("id NOT IN (?)", gormDB.Model(&models.SwipeEvent{}).Select("undone_event_id").Where("undone_event_id IS NOT NULL")).
+		Order("user_id, created_at, id").
+		Scan(&swipes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	animals, err := loadAnimals(gormDB)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := loadSettings(gormDB)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []ranking.Session
+	for start := 0; start < len(swipes); {
+		end := start
+		for end < len(swipes) && swipes[end].UserID == swipes[start].UserID {
+			end++
+		}
+		if session, ok := buildSession(swipes[start:end], animals, settings, holdout, minSwipes); ok {
+			sessions = append(sessions, session)
+		}
+		start = end
+	}
+	return sessions, nil
+}
+
+func buildSession(history []swipe, animals map[uint]models.Animal, settings map[uuid.UUID]models.UserSettings, holdout float64, minSwipes int) (ranking.Session, bool) {
+	if len(history) < minSwipes {
+		return ranking.Session{}, false
+	}
+	split := len(history) - int(float64(len(history))*holdout)
+	if split <= 0 || split >= len(history) {
+		return ranking.Session{}, false
+	}
+
+	profile := ranking.NewProfile(settings[history[0].UserID], nil)
+	for _, s := range history[:split] {
+		if a, ok := animals[s.AnimalID]; ok {
+			profile.Add(ranking.Signal{AnimalType: a.Type, Gender: a.Gender, Age: a.Age, Liked: s.Liked})
+		}
+	}
+
+	session := ranking.Session{Profile: profile, Now: history[split].CreatedAt, Liked: map[uint]bool{}}
+	seen := map[uint]bool{}
+	for _, s := range history[split:] {
+		a, ok := animals[s.AnimalID]
+		if !ok || seen[s.AnimalID] {
+			continue
+		}
+		seen[s.AnimalID] = true
+		session.Candidates = append(session.Candidates, a)
+		session.Liked[s.AnimalID] = s.Liked
+	}
+	return session, true
+}
+
+func loadAnimals(gormDB *gorm.DB) (map[uint]models.Animal, error) {
+	var animals []models.Animal
+	if err := gormDB.Unscoped().Find(&animals).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]models.Animal, len(animals))
+	for _, a := range animals {
+		result[a.ID] = a
+	}
+	return result, nil
+}
+
+func loadSettings(gormDB *gorm.DB) (map[uuid.UUID]models.UserSettings, error) {
+	var settings []models.UserSettings
+	if err := gormDB.Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]models.UserSettings, len(settings))
+	for _, s := range settings {
+		result[s.UserID] = s
+	}
+	return result, nil
+}
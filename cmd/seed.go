@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/auth"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// seedPassword is the password of every seeded user.
+const seedPassword = "findyourpet"
+
+// seedBatchSize is how many animals are inserted at a time.
+const seedBatchSize = 100
+
+var (
+	seedNames = []string{"Luna", "Max", "Bella", "Charlie", "Milo", "Daisy", "Rocky", "Simba", "Nala", "Oscar",
+		"Lucy", "Leo", "Coco", "Bobik", "Murka", "Barsik", "Sirko", "Pushok", "Ginger", "Zefir"}
+	seedTypes        = []string{"dog", "dog", "dog", "cat", "cat", "cat", "parrot", "rabbit"}
+	seedTemperaments = []string{"Calm", "Playful", "Shy at first", "Very friendly", "Curious", "Gentle"}
+	seedHabits       = []string{"loves long walks", "gets along with other pets", "is good with kids",
+		"enjoys sleeping in the sun", "knows a few commands", "likes to be brushed", "is house trained"}
+	seedStatuses = []string{models.AnimalStatusAvailable, models.AnimalStatusAvailable, models.AnimalStatusAvailable,
+		models.AnimalStatusReserved, models.AnimalStatusAdopted}
+	// seedCities are centers around which listings are spread.
+	seedCities = [][2]float64{{50.4501, 30.5234}, {49.8397, 24.0297}, {46.4825, 30.7233}, {49.9935, 36.2304}}
+)
+
+// runSeed fills the database with fake users and their listings, for
+// development. Every user has the seed password, so other environments are
+// only seeded with -force.
+func runSeed(app *app, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	userCount := flags.Int("users", 10, "users to create")
+	animalCount := flags.Int("animals", 100, "animals to create, spread among the users")
+	seed := flags.Int64("seed", 1, "seed of the generated data")
+	withImages := flags.Bool("images", false, "generate and upload an image per animal to the configured storage")
+	force := flags.Bool("force", false, "seed outside the development environment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !isDevEnv() && !*force {
+		return fmt.Errorf("refusing to seed the %q environment, its users would share a known password; use -force to seed anyway", viper.GetString(environment))
+	}
+	if *userCount < 1 || *animalCount < 0 {
+		return errors.New("seed needs at least one user and no negative count of animals")
+	}
+	random := rand.New(rand.NewSource(*seed))
+
+	var photoService *photos.PhotoService
+	if *withImages {
+		blobStore, _, err := newBlobStore(app.config)
+		if err != nil {
+			return err
+		}
+		photoService = photos.NewPhotoService(blobStore)
+	}
+
+	hash, err := auth.NewAuthService().GenerateHashFromPassword(seedPassword)
+	if err != nil {
+		return err
+	}
+	userStore := users.NewUserStore(app.db)
+	owners := make([]*models.User, 0, *userCount)
+	for i := 0; i < *userCount; i++ {
+		user := &models.User{
+			Email:        fmt.Sprintf("seed%d.%d@findyourpet.test", *seed, i+1),
+			Password:     hash,
+			Locale:       mail.DefaultLocale,
+			UserSettings: constants.DefaultUserSettings,
+		}
+		if err := userStore.Create(user); err != nil {
+			return errors.Wrapf(err, "user %s", user.Email)
+		}
+		owners = append(owners, user)
+	}
+
+	animalStore := animals.NewAnimalStore(app.db, app.config.ReshowPolicy)
+	batch := make([]*models.Animal, 0, seedBatchSize)
+	for i := 0; i < *animalCount; i++ {
+		a := fakeAnimal(random, owners[random.Intn(len(owners))])
+		if photoService != nil {
+			if err := addFakeImage(random, photoService, a); err != nil {
+				return err
+			}
+		}
+		batch = append(batch, a)
+		if len(batch) == seedBatchSize || i == *animalCount-1 {
+			if err := animalStore.AddAnimals(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	fmt.Printf("created %d users with password %q and %d animals\n", *userCount, seedPassword, *animalCount)
+	return nil
+}
+
+func fakeAnimal(random *rand.Rand, owner *models.User) *models.Animal {
+	pick := func(values []string) string { return values[random.Intn(len(values))] }
+
+	gender := constants.MALE
+	if random.Intn(2) == 0 {
+		gender = constants.FEMALE
+	}
+	city := seedCities[random.Intn(len(seedCities))]
+	latitude := city[0] + (random.Float64()-0.5)*0.3
+	longitude := city[1] + (random.Float64()-0.5)*0.3
+	a := &models.Animal{
+		Name:        pick(seedNames),
+		Age:         float32(random.Intn(30)+1) / 2,
+		Type:        pick(seedTypes),
+		Description: fmt.Sprintf("%s, %s and %s.", pick(seedTemperaments), pick(seedHabits), pick(seedHabits)),
+		Gender:      gender,
+		Vaccinated:  random.Intn(4) > 0,
+		Sterilized:  random.Intn(2) == 0,
+		Latitude:    &latitude,
+		Longitude:   &longitude,
+		Status:      pick(seedStatuses),
+		OwnerID:     &owner.ID,
+	}
+	if random.Intn(3) == 0 {
+		fee := float64(random.Intn(20)+1) * 50
+		a.Fee = &fee
+	}
+	return a
+}
+
+// addFakeImage uploads a gradient of random colours as the image of the
+// animal.
+func addFakeImage(random *rand.Rand, photoService *photos.PhotoService, a *models.Animal) error {
+	from := color.RGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255}
+	to := color.RGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255}
+	const width, height = 640, 480
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t := float64(x+y) / float64(width+height)
+			mix := func(a, b uint8) uint8 { return uint8(float64(a)*(1-t) + float64(b)*t) }
+			img.SetRGBA(x, y, color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	photo, err := photoService.UploadPhotoFromReader(&buf, a.Name+"_image")
+	if err != nil {
+		return err
+	}
+	a.Image = models.Image{URL: photo.ImageURL, Key: photo.Key, Variants: photo.Variants, PerceptualHash: photo.PerceptualHash}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/initializers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/conversations"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/notifications"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/searches"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/webhooks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// runServe starts the API server and its background jobs.
+func runServe(app *app, args []string) error {
+	if len(args) > 0 {
+		return errors.New("usage: serve")
+	}
+	configuration, gormDB := app.config, app.db
+
+	// The schema is changed by `migrate up` only, never by the server.
+	if err := checkSchema(app); err != nil {
+		return err
+	}
+
+	userStore := users.NewUserStore(gormDB)
+	animalStore := animals.NewAnimalStore(gormDB, configuration.ReshowPolicy)
+	blobStore, files, err := newBlobStore(configuration)
+	if err != nil {
+		return errors.Wrap(err, "unable to set up file storage")
+	}
+	photoService := photos.NewPhotoService(blobStore)
+	eventBus := events.NewBus(constants.EventHistorySize)
+	webhookService := services.NewWebhookService(webhooks.NewWebhookStore(gormDB), netguard.NewPublicClient(constants.WebhookTimeout))
+	go webhookService.Start(context.Background(), constants.WebhookJobTick)
+	notificationService := services.NewNotificationService(notifications.NewNotificationStore(gormDB), eventBus)
+	animalService := services.NewAnimalService(animalStore, userStore, photoService, ranking.NewDefaultRanker(), notificationService, eventBus, webhookService)
+	importService := services.NewImportService(animalStore, photoService, netguard.NewPublicClient(constants.ImportFetchTimeout))
+
+	emails, err := mail.NewRenderer()
+	if err != nil {
+		return errors.Wrap(err, "unable to load email templates")
+	}
+	outboxStore := outbox.NewOutboxStore(gormDB)
+	emailNotifier := services.NewEmailNotifier(userStore, outboxStore, emails)
+	if smtp := configuration.SMTP; smtp != nil {
+		mailer := mail.NewSMTPMailer(smtp.Host, smtp.Port, smtp.Username, smtp.Password, smtp.From)
+		go services.NewEmailService(outboxStore, mailer).Start(context.Background(), constants.EmailJobTick)
+	} else {
+		log.Warn().Msg("SMTP is not configured, emails stay in the outbox")
+	}
+	// Digests link to the API to unsubscribe, they are not emailed without
+	// an absolute URL to it.
+	var digestEmails services.NotifierI
+	if configuration.PublicURL != "" {
+		digestEmails = emailNotifier
+	} else {
+		log.Warn().Msg("PUBLIC_URL is not set, saved search digests are not emailed")
+	}
+	savedSearchService := services.NewSavedSearchService(searches.NewSavedSearchStore(gormDB), animalStore,
+		notificationService, digestEmails, configuration.PublicURL, configuration.SavedSearchInterval)
+	go savedSearchService.Start(context.Background(), constants.SavedSearchJobTick)
+
+	conversationService := services.NewConversationService(conversations.NewConversationStore(gormDB), animalStore, photoService, eventBus)
+
+	router := initializers.NewRouter(gormDB, userStore, animalStore, animalService, importService, savedSearchService, notificationService, conversationService, webhookService, eventBus, emails, files, configuration.PublicURL)
+
+	ginEngine := gin.Default()
+	router.SetupAPIs(ginEngine)
+
+	return ginEngine.Run(configuration.GinPort)
+}
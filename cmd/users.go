@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/auth"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const userUsage = "usage: user create-admin | reset-password -email address [-password password]"
+
+// runUser manages accounts that can not be managed through the API.
+// Passwords that are not given are generated and printed.
+func runUser(app *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New(userUsage)
+	}
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "new password, generated when empty")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New(userUsage)
+	}
+
+	store := users.NewUserStore(app.db)
+	switch args[0] {
+	case "create-admin":
+		return createAdmin(store, *email, *password)
+	case "reset-password":
+		return resetPassword(store, *email, *password)
+	default:
+		return errors.New(userUsage)
+	}
+}
+
+// createAdmin promotes the user, creating them first if needed.
+func createAdmin(store users.UserStoreI, email, password string) error {
+	user, err := store.GetByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		hash, err := hashPassword(&password)
+		if err != nil {
+			return err
+		}
+		user = &models.User{
+			Email:        email,
+			Password:     hash,
+			Locale:       mail.DefaultLocale,
+			Role:         models.RoleAdmin,
+			UserSettings: constants.DefaultUserSettings,
+		}
+		if err := store.Create(user); err != nil {
+			return err
+		}
+		fmt.Printf("created admin %s with password %s\n", email, password)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	user.Role = models.RoleAdmin
+	if password != "" {
+		if user.Password, err = hashPassword(&password); err != nil {
+			return err
+		}
+	}
+	if err := store.UpdateUser(user); err != nil {
+		return err
+	}
+	fmt.Printf("%s is now an admin\n", email)
+	return nil
+}
+
+func resetPassword(store users.UserStoreI, email, password string) error {
+	user, err := store.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user.Password, err = hashPassword(&password); err != nil {
+		return err
+	}
+	if err := store.UpdateUser(user); err != nil {
+		return err
+	}
+	fmt.Printf("password of %s is now %s\n", email, password)
+	return nil
+}
+
+// hashPassword generates the password when it is empty.
+func hashPassword(password *string) (string, error) {
+	if *password == "" {
+		random := make([]byte, 12)
+		if _, err := rand.Read(random); err != nil {
+			return "", err
+		}
+		*password = base64.RawURLEncoding.EncodeToString(random)
+	}
+	return auth.NewAuthService().GenerateHashFromPassword(*password)
+}
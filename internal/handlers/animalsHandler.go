@@ -3,11 +3,18 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 type AnimalsHandler struct {
@@ -18,7 +25,14 @@ func NewAnimalsHandler(animalService services.AnimalServiceI) *AnimalsHandler {
 	return &AnimalsHandler{animalService: animalService}
 }
 
+// AddAnimal accepts the animal as JSON with base64 photos, or as a
+// multipart form with the animal JSON followed by the image and photo files.
 func (h *AnimalsHandler) AddAnimal(c *gin.Context) {
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		h.addAnimalWithFiles(c)
+		return
+	}
+
 	var body models.AnimalJSON
 	if err := c.BindJSON(&body); err != nil {
 		log.Info().Err(err).Send()
@@ -26,13 +40,245 @@ func (h *AnimalsHandler) AddAnimal(c *gin.Context) {
 		return
 	}
 
-	if err := h.animalService.AddAnimal(&body); err != nil {
-		log.Info().Err(err).Msg("Cant store animal record")
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	duplicates, err := h.animalService.AddAnimal(user.ID, &body)
+	if err != nil {
+		uploadError(c, err, "Cant store animal record")
+		return
+	}
+	c.JSON(http.StatusOK, addedAnimalResponse(duplicates))
+
+}
+
+func (h *AnimalsHandler) addAnimalWithFiles(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxUploadSize)
+	files, err := uploads.NewMultipartReader(c.Request, constants.MaxPhotoSize, constants.MaxPhotos)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	var body models.AnimalJSON
+	if err := files.ReadJSON(constants.AnimalFormField, &body); err != nil {
+		uploadError(c, err, "Cant read animal record")
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	duplicates, err := h.animalService.AddAnimalWithFiles(user.ID, &body, files)
+	if err != nil {
+		uploadError(c, err, "Cant store animal record")
+		return
+	}
+	c.JSON(http.StatusOK, addedAnimalResponse(duplicates))
+}
+
+// addedAnimalResponse warns the poster about listings that show the same
+// pictures. They are reviewed by moderators, the new listing stays online.
+func addedAnimalResponse(duplicates []models.DuplicateWarningJSON) gin.H {
+	if len(duplicates) == 0 {
+		return gin.H{}
+	}
+	return gin.H{"warnings": gin.H{"duplicates": duplicates}}
+}
+
+// PresignPhotoUploads issues URLs the owner uploads photos of the animal to
+// directly, bypassing the API.
+func (h *AnimalsHandler) PresignPhotoUploads(c *gin.Context) {
+	var body models.PhotoUploadsJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	presigned, err := h.animalService.PresignPhotoUploads(user.ID, c.Param("id"), body.Files)
+	if err != nil {
+		uploadError(c, err, "Cant presign photo uploads")
+		return
+	}
+	c.JSON(http.StatusOK, presigned)
+}
+
+// AddPhotos adds photos to the animal: either the ones uploaded with
+// presigned URLs, confirmed by their keys as JSON, or photo files of a
+// multipart form.
+func (h *AnimalsHandler) AddPhotos(c *gin.Context) {
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		h.addPhotosWithFiles(c)
+		return
+	}
+
+	var body models.PhotoConfirmJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	photos, err := h.animalService.ConfirmPhotoUploads(user.ID, c.Param("id"), body.Keys)
+	if err != nil {
+		uploadError(c, err, "Cant confirm photo uploads")
+		return
+	}
+	c.JSON(http.StatusOK, models.ToPhotoJSONArray(photos))
+}
+
+func (h *AnimalsHandler) addPhotosWithFiles(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxUploadSize)
+	files, err := uploads.NewMultipartReader(c.Request, constants.MaxPhotoSize, constants.MaxPhotos)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	photos, err := h.animalService.AddPhotosWithFiles(user.ID, c.Param("id"), files)
+	if err != nil {
+		uploadError(c, err, "Cant add photos")
+		return
+	}
+	c.JSON(http.StatusOK, models.ToPhotoJSONArray(photos))
+}
+
+// DeletePhoto removes a photo of the animal along with its files.
+func (h *AnimalsHandler) DeletePhoto(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
 		c.Status(http.StatusBadRequest)
 		return
 	}
+
+	if err := h.animalService.DeletePhoto(user.ID, c.Param("id"), c.Param("photoId")); err != nil {
+		uploadError(c, err, "Cant delete photo")
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ReorderPhotos moves photos of the animal to explicit positions.
+func (h *AnimalsHandler) ReorderPhotos(c *gin.Context) {
+	var body models.PhotoOrderJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.animalService.ReorderPhotos(user.ID, c.Param("id"), body.Photos)
+	if err != nil {
+		uploadError(c, err, "Cant reorder photos")
+		return
+	}
+	c.JSON(http.StatusOK, models.ToPhotoJSONArray(animal.Photos))
+}
 
+// SetCover makes one of the animal's photos its image.
+func (h *AnimalsHandler) SetCover(c *gin.Context) {
+	var body models.CoverJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.animalService.SetCover(user.ID, c.Param("id"), body.PhotoID)
+	if err != nil {
+		uploadError(c, err, "Cant set cover photo")
+		return
+	}
+	c.JSON(http.StatusOK, models.ToAnimalJSON(animal))
+}
+
+func uploadError(c *gin.Context, err error, msg string) {
+	if status, ok := uploadStatus(err); ok {
+		c.JSON(status, gin.H{"Error": err.Error()})
+		return
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"Error": "Animal not found"})
+	case errors.Is(err, services.ErrPhotoNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"Error": err.Error()})
+	case errors.Is(err, services.ErrNotAnimalOwner):
+		c.JSON(http.StatusForbidden, gin.H{"Error": err.Error()})
+	case errors.Is(err, services.ErrInvalidUploadKey), errors.Is(err, services.ErrUploadNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+	default:
+		log.Info().Err(err).Msg(msg)
+		c.Status(http.StatusBadRequest)
+	}
+}
+
+// uploadStatus returns the response status for errors about uploaded files,
+// which are the client's fault and safe to show.
+func uploadStatus(err error) (int, bool) {
+	var maxBytes *http.MaxBytesError
+	switch {
+	case errors.Is(err, uploads.ErrFileTooLarge), errors.As(err, &maxBytes):
+		return http.StatusRequestEntityTooLarge, true
+	case errors.Is(err, uploads.ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType, true
+	case errors.Is(err, uploads.ErrMalformedImage), errors.Is(err, uploads.ErrImageDimensions), errors.Is(err, photos.ErrInvalidDataURI),
+		errors.Is(err, uploads.ErrTooManyFiles), errors.Is(err, uploads.ErrUnexpectedPart), errors.Is(err, uploads.ErrMissingPart):
+		return http.StatusBadRequest, true
+	}
+	return 0, false
 }
 
 func (h *AnimalsHandler) GetAnimals(c *gin.Context) {
@@ -49,12 +295,7 @@ func (h *AnimalsHandler) GetAnimals(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.PaginatedContent[models.AnimalJSON]{
-		Data:       models.ToAnimalJSONArray(animals),
-		Page:       c.GetInt("page"),
-		PageSize:   c.GetInt("pageSize"),
-		TotalPages: c.GetInt("totalPages"),
-	})
+	c.JSON(http.StatusOK, paginatedAnimals(c, animals))
 }
 func (h *AnimalsHandler) GetAllAnimals(c *gin.Context) {
 	animals, err := h.animalService.GetAllAnimals(c)
@@ -64,12 +305,7 @@ func (h *AnimalsHandler) GetAllAnimals(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.PaginatedContent[models.AnimalJSON]{
-		Data:       models.ToAnimalJSONArray(animals),
-		Page:       c.GetInt("page"),
-		PageSize:   c.GetInt("pageSize"),
-		TotalPages: c.GetInt("totalPages"),
-	})
+	c.JSON(http.StatusOK, paginatedAnimals(c, animals))
 }
 
 func (h *AnimalsHandler) GetLikedAnimals(c *gin.Context) {
@@ -87,12 +323,7 @@ func (h *AnimalsHandler) GetLikedAnimals(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.PaginatedContent[models.AnimalJSON]{
-		Data:       models.ToAnimalJSONArray(animals),
-		Page:       c.GetInt("page"),
-		PageSize:   c.GetInt("pageSize"),
-		TotalPages: c.GetInt("totalPages"),
-	})
+	c.JSON(http.StatusOK, paginatedAnimals(c, animals))
 }
 
 func (h *AnimalsHandler) GetAnimalByID(c *gin.Context) {
@@ -110,6 +341,35 @@ func (h *AnimalsHandler) GetAnimalByID(c *gin.Context) {
 	c.JSON(http.StatusOK, models.ToAnimalJSON(animal))
 }
 
+func (h *AnimalsHandler) UpdateAnimal(c *gin.Context) {
+	animalId := c.Param("id")
+	if animalId == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	var body models.AnimalUpdateJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.animalService.UpdateAnimal(user.ID, animalId, &body)
+	if err != nil {
+		uploadError(c, err, "Cant update animal record")
+		return
+	}
+	c.JSON(http.StatusOK, models.ToAnimalJSON(animal))
+}
+
 func (h *AnimalsHandler) MarkAsSeen(c *gin.Context) {
 	animalId := c.Param("id")
 	if animalId == "" {
@@ -133,7 +393,7 @@ func (h *AnimalsHandler) MarkAsSeen(c *gin.Context) {
 		return
 	}
 
-	if err := h.animalService.MarkAsSeen(animalId, user.ID, body.Like); err != nil {
+	if err := h.animalService.MarkAsSeen(animalId, user.ID, body.Like, swipeClient(c)); err != nil {
 		log.Info().Err(err).Msg("Cant mark as seen")
 		c.Status(http.StatusBadRequest)
 		return
@@ -143,6 +403,36 @@ func (h *AnimalsHandler) MarkAsSeen(c *gin.Context) {
 
 }
 
+// swipeClient identifies the app a swipe came from, falling back to the user agent.
+func swipeClient(c *gin.Context) string {
+	client := c.GetHeader(constants.ClientHeader)
+	if client == "" {
+		client = c.Request.UserAgent()
+	}
+	// Postgres rejects invalid UTF-8, so stray bytes are dropped and the cut
+	// is made at the start of a character.
+	client = strings.ToValidUTF8(client, "")
+	if len(client) > 255 {
+		end := 255
+		for !utf8.RuneStart(client[end]) {
+			end--
+		}
+		client = client[:end]
+	}
+	return client
+}
+
+func paginatedAnimals(c *gin.Context, animals []models.Animal) models.PaginatedContent[models.AnimalJSON] {
+	return models.PaginatedContent[models.AnimalJSON]{
+		Data:       models.ToAnimalJSONArray(animals),
+		Page:       c.GetInt("page"),
+		PageSize:   c.GetInt("pageSize"),
+		TotalPages: c.GetInt("totalPages"),
+		Cursor:     c.GetString("cursor"),
+		NextCursor: c.GetString("nextCursor"),
+	}
+}
+
 func getUserDataFromContext(c *gin.Context) (*models.User, error) {
 	u, ok := c.Get("user")
 
@@ -3,13 +3,18 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -34,12 +39,318 @@ func TestAnimalsHandler_AddAnimal(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/animal", bytes.NewBuffer(userJSON))
 		r.Header.Set("Content-Type", "application/json")
 		c.Request = r
+		userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+		c.Set("user", userMock)
+
+		animalServiceMock.EXPECT().AddAnimal(userMock.ID, &reqBody).Return(nil, nil)
+
+		animalsHandler.AddAnimal(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{}`, w.Body.String())
+	})
+
+	t.Run("Duplicate listings are reported", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		reqBody := models.AnimalJSON{Name: "TEST", Age: 1, Type: "cat", Description: "qwerty", Gender: "MALE"}
+		animalJSON, _ := json.Marshal(reqBody)
+		c.Request, _ = http.NewRequest("POST", "/animal", bytes.NewBuffer(animalJSON))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("user", &models.User{ID: uuid.New()})
+
+		animalServiceMock.EXPECT().AddAnimal(gomock.Any(), gomock.Any()).Return([]models.DuplicateWarningJSON{{AnimalID: 7, Distance: 2}}, nil)
+
+		animalsHandler.AddAnimal(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"warnings":{"duplicates":[{"animalId":7,"distance":2}]}}`, w.Body.String())
+	})
+
+	t.Run("Rejected images", func(t *testing.T) {
+		for err, status := range map[error]int{
+			uploads.ErrUnsupportedType: http.StatusUnsupportedMediaType,
+			uploads.ErrMalformedImage:  http.StatusBadRequest,
+			uploads.ErrImageDimensions: http.StatusBadRequest,
+			uploads.ErrFileTooLarge:    http.StatusRequestEntityTooLarge,
+		} {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reqBody := models.AnimalJSON{Name: "TEST", Age: 1, Type: "cat", Description: "qwerty", Gender: "MALE", Image: "data:image/png;base64,AAAA"}
+			animalJSON, _ := json.Marshal(reqBody)
+			c.Request, _ = http.NewRequest("POST", "/animal", bytes.NewBuffer(animalJSON))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user", &models.User{ID: uuid.New()})
+
+			animalServiceMock.EXPECT().AddAnimal(gomock.Any(), gomock.Any()).Return(nil, err)
+
+			animalsHandler.AddAnimal(c)
+
+			assert.Equal(t, status, w.Code)
+			assert.Contains(t, w.Body.String(), err.Error())
+		}
+	})
+}
+
+func TestAnimalsHandler_AddAnimalWithFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	animalServiceMock := mocks.NewMockAnimalServiceI(ctrl)
+	animalsHandler := handlers.NewAnimalsHandler(animalServiceMock)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	newRequest := func(animal string) *http.Request {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		field, _ := writer.CreateFormField("animal")
+		io.WriteString(field, animal)
+		file, _ := writer.CreateFormFile("image", "luna.jpg")
+		io.WriteString(file, "image")
+		writer.Close()
+
+		r, _ := http.NewRequest("POST", "/animal", &body)
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+		return r
+	}
+
+	t.Run("Successful AddAnimal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request = newRequest(`{"name":"Luna","age":1,"type":"cat","description":"qwerty","gender":"FEMALE"}`)
+
+		animalServiceMock.EXPECT().AddAnimalWithFiles(userMock.ID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ownerID uuid.UUID, animal *models.AnimalJSON, files uploads.FileReaderI) ([]models.DuplicateWarningJSON, error) {
+				assert.Equal(t, "Luna", animal.Name)
+				file, err := files.Next()
+				assert.NoError(t, err)
+				assert.Equal(t, "image", file.Field)
+				return nil, nil
+			})
+
+		animalsHandler.AddAnimal(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid animal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request = newRequest(`{"name":"Luna"}`)
+
+		animalsHandler.AddAnimal(c)
 
-		animalServiceMock.EXPECT().AddAnimal(&reqBody).Return(nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("File too large", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request = newRequest(`{"name":"Luna","age":1,"type":"cat","description":"qwerty","gender":"FEMALE"}`)
+
+		animalServiceMock.EXPECT().AddAnimalWithFiles(userMock.ID, gomock.Any(), gomock.Any()).Return(nil, uploads.ErrFileTooLarge)
 
 		animalsHandler.AddAnimal(c)
 
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestAnimalsHandler_PresignPhotoUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	animalServiceMock := mocks.NewMockAnimalServiceI(ctrl)
+	animalsHandler := handlers.NewAnimalsHandler(animalServiceMock)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+	files := []models.PhotoUploadJSON{{ContentType: "image/jpeg", Size: 2048}}
+
+	newContext := func(w *httptest.ResponseRecorder) *gin.Context {
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		body, _ := json.Marshal(models.PhotoUploadsJSON{Files: files})
+		c.Request, _ = http.NewRequest("POST", "/animal/3/photos/uploads", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c
+	}
+
+	t.Run("Successful PresignPhotoUploads", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := newContext(w)
+		presigned := []models.PresignedUpload{{Key: "animals/3/uploads/a.jpg", Method: http.MethodPut, URL: "https://s3/animals/3/uploads/a.jpg"}}
+		animalServiceMock.EXPECT().PresignPhotoUploads(userMock.ID, "3", files).Return(presigned, nil)
+
+		animalsHandler.PresignPhotoUploads(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []models.PresignedUpload
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, presigned[0].Key, response[0].Key)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := newContext(w)
+		animalServiceMock.EXPECT().PresignPhotoUploads(userMock.ID, "3", files).Return(nil, services.ErrNotAnimalOwner)
+
+		animalsHandler.PresignPhotoUploads(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := newContext(w)
+		animalServiceMock.EXPECT().PresignPhotoUploads(userMock.ID, "3", files).Return(nil, uploads.ErrUnsupportedType)
+
+		animalsHandler.PresignPhotoUploads(c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+func TestAnimalsHandler_ConfirmPhotoUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	animalServiceMock := mocks.NewMockAnimalServiceI(ctrl)
+	animalsHandler := handlers.NewAnimalsHandler(animalServiceMock)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+	keys := []string{"animals/3/uploads/a.jpg"}
+
+	t.Run("Successful ConfirmPhotoUploads", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		body, _ := json.Marshal(models.PhotoConfirmJSON{Keys: keys})
+		c.Request, _ = http.NewRequest("POST", "/animal/3/photos", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		animalServiceMock.EXPECT().ConfirmPhotoUploads(userMock.ID, "3", keys).
+			Return([]models.Photo{{AnimalID: 3, ImageURL: "https://s3/" + keys[0], Key: keys[0]}}, nil)
+
+		animalsHandler.AddPhotos(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []models.PhotoJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, keys[0], response[0].Key)
+	})
+
+	t.Run("Upload is missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		body, _ := json.Marshal(models.PhotoConfirmJSON{Keys: keys})
+		c.Request, _ = http.NewRequest("POST", "/animal/3/photos", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		animalServiceMock.EXPECT().ConfirmPhotoUploads(userMock.ID, "3", keys).Return(nil, services.ErrUploadNotFound)
+
+		animalsHandler.AddPhotos(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAnimalsHandler_ManagePhotos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	animalServiceMock := mocks.NewMockAnimalServiceI(ctrl)
+	animalsHandler := handlers.NewAnimalsHandler(animalServiceMock)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	request := func(method, path string, body interface{}, params ...gin.Param) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = append(gin.Params{{Key: "id", Value: "3"}}, params...)
+		data, _ := json.Marshal(body)
+		c.Request, _ = http.NewRequest(method, path, bytes.NewBuffer(data))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c, w
+	}
+
+	t.Run("Add photo files", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("photos", "a.jpg")
+		part.Write([]byte("photo"))
+		form.Close()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		c.Request, _ = http.NewRequest("POST", "/animal/3/photos", &body)
+		c.Request.Header.Set("Content-Type", form.FormDataContentType())
+
+		animalServiceMock.EXPECT().AddPhotosWithFiles(userMock.ID, "3", gomock.Any()).
+			Return([]models.Photo{{AnimalID: 3, Key: "a_full.jpg", Position: 2}}, nil)
+
+		animalsHandler.AddPhotos(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []models.PhotoJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 2, response[0].Position)
+	})
+
+	t.Run("Delete photo", func(t *testing.T) {
+		c, w := request("DELETE", "/animal/3/photos/5", nil, gin.Param{Key: "photoId", Value: "5"})
+		animalServiceMock.EXPECT().DeletePhoto(userMock.ID, "3", "5").Return(nil)
+
+		animalsHandler.DeletePhoto(c)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		c, w = request("DELETE", "/animal/3/photos/6", nil, gin.Param{Key: "photoId", Value: "6"})
+		animalServiceMock.EXPECT().DeletePhoto(userMock.ID, "3", "6").Return(services.ErrPhotoNotFound)
+
+		animalsHandler.DeletePhoto(c)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Reorder photos", func(t *testing.T) {
+		positions := []models.PhotoPositionJSON{{ID: 5, Position: 0}, {ID: 4, Position: 1}}
+		c, w := request("PUT", "/animal/3/photos/order", models.PhotoOrderJSON{Photos: positions})
+		animal := models.Animal{Photos: []models.Photo{{Key: "e.jpg"}, {Key: "d.jpg", Position: 1}}}
+		animalServiceMock.EXPECT().ReorderPhotos(userMock.ID, "3", positions).Return(animal, nil)
+
+		animalsHandler.ReorderPhotos(c)
+
 		assert.Equal(t, http.StatusOK, w.Code)
+		var response []models.PhotoJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "e.jpg", response[0].Key)
+
+		c, w = request("PUT", "/animal/3/photos/order", models.PhotoOrderJSON{Photos: []models.PhotoPositionJSON{{ID: 5, Position: -1}}})
+		animalsHandler.ReorderPhotos(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Set cover", func(t *testing.T) {
+		c, w := request("PUT", "/animal/3/cover", models.CoverJSON{PhotoID: 5})
+		animal := models.Animal{Image: models.Image{URL: "https://s3/e_full.jpg", Key: "e_full.jpg"}}
+		animalServiceMock.EXPECT().SetCover(userMock.ID, "3", uint(5)).Return(animal, nil)
+
+		animalsHandler.SetCover(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.AnimalJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://s3/e_full.jpg", response.Image)
+
+		c, w = request("PUT", "/animal/3/cover", models.CoverJSON{PhotoID: 5})
+		animalServiceMock.EXPECT().SetCover(userMock.ID, "3", uint(5)).Return(models.Animal{}, services.ErrNotAnimalOwner)
+
+		animalsHandler.SetCover(c)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -103,13 +414,75 @@ func TestAnimalsHandler_MarkAsSeen(t *testing.T) {
 
 		r, _ := http.NewRequest("POST", "/animals/1/seen", bytes.NewBuffer(body))
 		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Client", "web/1.0.0")
+		c.Request = r
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		animalServiceMock.EXPECT().MarkAsSeen("1", userMock.ID, true, "web/1.0.0").Return(nil)
+
+		animalsHandler.MarkAsSeen(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Long client is cut between characters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", &models.User{ID: uuid.New()})
+
+		// 254 bytes followed by a two byte character.
+		client := strings.Repeat("a", 254) + "é"
+		r, _ := http.NewRequest("POST", "/animals/1/seen", strings.NewReader(`{"like":false}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Client", client)
 		c.Request = r
 		c.Params = gin.Params{{Key: "id", Value: "1"}}
 
-		animalServiceMock.EXPECT().MarkAsSeen("1", userMock.ID, true).Return(nil)
+		animalServiceMock.EXPECT().MarkAsSeen("1", gomock.Any(), false, strings.Repeat("a", 254)).Return(nil)
 
 		animalsHandler.MarkAsSeen(c)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestAnimalsHandler_UpdateAnimal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	animalServiceMock := mocks.NewMockAnimalServiceI(ctrl)
+	animalsHandler := handlers.NewAnimalsHandler(animalServiceMock)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+	status := models.AnimalStatusAdopted
+
+	newContext := func(w *httptest.ResponseRecorder) *gin.Context {
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		body, _ := json.Marshal(models.AnimalUpdateJSON{Status: &status})
+		c.Request, _ = http.NewRequest("PATCH", "/animal/3", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c
+	}
+
+	t.Run("Successful UpdateAnimal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := newContext(w)
+		animalServiceMock.EXPECT().UpdateAnimal(userMock.ID, "3", &models.AnimalUpdateJSON{Status: &status}).
+			Return(models.Animal{Status: status}, nil)
+
+		animalsHandler.UpdateAnimal(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c := newContext(w)
+		animalServiceMock.EXPECT().UpdateAnimal(userMock.ID, "3", gomock.Any()).Return(models.Animal{}, services.ErrNotAnimalOwner)
+
+		animalsHandler.UpdateAnimal(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type ConversationsHandler struct {
+	service services.ConversationServiceI
+}
+
+func NewConversationsHandler(service services.ConversationServiceI) *ConversationsHandler {
+	return &ConversationsHandler{service: service}
+}
+
+func (h *ConversationsHandler) StartConversation(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	conversation, err := h.service.StartConversation(user.ID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Animal not found"})
+			return
+		}
+		conversationError(c, err, "Cant start conversation")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToConversationJSON(conversation, user.ID))
+}
+
+func (h *ConversationsHandler) GetConversations(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	conversations, err := h.service.GetConversations(user.ID, c)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get conversations")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedContent[models.ConversationJSON]{
+		Data:       models.ToConversationJSONArray(conversations, user.ID),
+		Page:       c.GetInt("page"),
+		PageSize:   c.GetInt("pageSize"),
+		TotalPages: c.GetInt("totalPages"),
+	})
+}
+
+func (h *ConversationsHandler) GetMessages(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	messages, conversation, err := h.service.GetMessages(user.ID, c.Param("id"), c)
+	if err != nil {
+		conversationError(c, err, "Cant get messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedContent[models.MessageJSON]{
+		Data:       models.ToMessageJSONArray(messages, conversation, user.ID),
+		Page:       c.GetInt("page"),
+		PageSize:   c.GetInt("pageSize"),
+		TotalPages: c.GetInt("totalPages"),
+		Cursor:     c.GetString("cursor"),
+		NextCursor: c.GetString("nextCursor"),
+	})
+}
+
+func (h *ConversationsHandler) SendMessage(c *gin.Context) {
+	var body models.MessageSendJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	message, conversation, err := h.service.SendMessage(user.ID, c.Param("id"), &body)
+	if err != nil {
+		conversationError(c, err, "Cant send message")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToMessageJSON(message, conversation, user.ID))
+}
+
+func (h *ConversationsHandler) MarkAsRead(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkAsRead(user.ID, c.Param("id")); err != nil {
+		conversationError(c, err, "Cant mark conversation as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *ConversationsHandler) BlockParticipant(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.BlockParticipant(user.ID, c.Param("id")); err != nil {
+		conversationError(c, err, "Cant block participant")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *ConversationsHandler) ReportParticipant(c *gin.Context) {
+	var body models.ReportJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ReportParticipant(user.ID, c.Param("id"), &body); err != nil {
+		conversationError(c, err, "Cant report participant")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// conversationError responds with the status matching a messaging error.
+func conversationError(c *gin.Context, err error, msg string) {
+	if status, ok := uploadStatus(err); ok {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrNotParticipating):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation not found"})
+	case errors.Is(err, services.ErrBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoListingOwner), errors.Is(err, services.ErrOwnListing):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Info().Err(err).Msg(msg)
+		c.Status(http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversationsHandler_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockConversationServiceI(ctrl)
+	conversationsHandler := handlers.NewConversationsHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+	owner := uuid.New()
+
+	body := models.MessageSendJSON{Body: "Is she still available?"}
+	reqBody, _ := json.Marshal(body)
+
+	newContext := func() (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "8"}}
+		c.Request, _ = http.NewRequest("POST", "/conversations/8/messages", bytes.NewBuffer(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		return c, w
+	}
+
+	t.Run("Successful SendMessage", func(t *testing.T) {
+		c, w := newContext()
+		conversation := models.Conversation{ID: 8, AdopterID: userMock.ID, OwnerID: owner, OwnerLastReadID: 21}
+		message := models.Message{ID: 21, ConversationID: 8, SenderID: userMock.ID, Body: body.Body}
+		mockService.EXPECT().SendMessage(userMock.ID, "8", &body).Return(message, conversation, nil)
+
+		conversationsHandler.SendMessage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MessageJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, body.Body, response.Body)
+		assert.True(t, response.Read)
+	})
+
+	t.Run("Rate limited", func(t *testing.T) {
+		c, w := newContext()
+		mockService.EXPECT().SendMessage(userMock.ID, "8", &body).Return(models.Message{}, models.Conversation{}, services.ErrRateLimited)
+
+		conversationsHandler.SendMessage(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		c, w := newContext()
+		mockService.EXPECT().SendMessage(userMock.ID, "8", &body).Return(models.Message{}, models.Conversation{}, services.ErrBlocked)
+
+		conversationsHandler.SendMessage(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Not a participant", func(t *testing.T) {
+		c, w := newContext()
+		mockService.EXPECT().SendMessage(userMock.ID, "8", &body).Return(models.Message{}, models.Conversation{}, services.ErrNotParticipating)
+
+		conversationsHandler.SendMessage(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// streamResync tells the client it missed events and has to refetch.
+	streamResync = "resync"
+	// streamHeartbeat is sent on idle WebSocket connections.
+	streamHeartbeat = "heartbeat"
+)
+
+type EventsHandler struct {
+	bus *events.Bus
+	// originHost is the only host WebSocket connections are accepted from.
+	// When empty, the origin has to match the host the request was sent to.
+	originHost string
+}
+
+func NewEventsHandler(bus *events.Bus, publicURL string) *EventsHandler {
+	h := &EventsHandler{bus: bus}
+	if u, err := url.Parse(publicURL); err == nil {
+		h.originHost = u.Host
+	}
+	return h
+}
+
+// streamMessage is an event as sent over a WebSocket.
+type streamMessage struct {
+	ID        uint64          `json:"id,omitempty"`
+	Topic     string          `json:"topic"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt *time.Time      `json:"createdAt,omitempty"`
+}
+
+// Stream sends the user's events as server-sent events. Clients reconnect with
+// the Last-Event-ID header to replay what they missed.
+func (h *EventsHandler) Stream(c *gin.Context) {
+	sub, err := h.subscribe(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer h.bus.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", constants.StreamRetry.Milliseconds())
+	if sub.Gap {
+		fmt.Fprintf(w, "id: %d\nevent: %s\ndata: {}\n\n", sub.LastID, streamResync)
+	} else {
+		for _, event := range sub.Replay {
+			writeSSE(w, event)
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(constants.StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			writeSSE(w, event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		w.Flush()
+	}
+}
+
+func writeSSE(w io.Writer, event events.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Topic, event.Data)
+}
+
+// StreamWebSocket sends the user's events as JSON messages over a WebSocket.
+// Clients reconnect with the last_event_id query parameter to replay what
+// they missed.
+func (h *EventsHandler) StreamWebSocket(c *gin.Context) {
+	sub, err := h.subscribe(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer h.bus.Unsubscribe(sub)
+
+	server := websocket.Server{Handshake: h.checkOrigin}
+	server.Handler = func(ws *websocket.Conn) {
+		// The client sends nothing; reading only notices when it goes away.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		send := func(message streamMessage) bool {
+			ws.SetWriteDeadline(time.Now().Add(constants.StreamHeartbeatInterval))
+			return websocket.JSON.Send(ws, message) == nil
+		}
+
+		if sub.Gap {
+			if !send(streamMessage{ID: sub.LastID, Topic: streamResync}) {
+				return
+			}
+		} else {
+			for _, event := range sub.Replay {
+				if !send(toStreamMessage(event)) {
+					return
+				}
+			}
+		}
+
+		heartbeat := time.NewTicker(constants.StreamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			var ok bool
+			select {
+			case <-closed:
+				return
+			case event, open := <-sub.Events():
+				if !open {
+					return
+				}
+				ok = send(toStreamMessage(event))
+			case <-heartbeat.C:
+				ok = send(streamMessage{Topic: streamHeartbeat})
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin rejects WebSocket handshakes from pages served by other sites,
+// which browsers would otherwise let ride on the user's cookies.
+func (h *EventsHandler) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return errors.New("missing origin")
+	}
+	host := h.originHost
+	if host == "" {
+		host = req.Host
+	}
+	if !strings.EqualFold(origin.Host, host) {
+		return fmt.Errorf("origin %q is not allowed", origin.Host)
+	}
+	config.Origin = origin
+	return nil
+}
+
+func toStreamMessage(event events.Event) streamMessage {
+	return streamMessage{ID: event.ID, Topic: string(event.Topic), Data: event.Data, CreatedAt: &event.CreatedAt}
+}
+
+// subscribe subscribes the user to the topics listed in the request, resuming
+// after the last event ID the client sent.
+func (h *EventsHandler) subscribe(c *gin.Context) (*events.Subscription, error) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []events.Topic
+	if list := c.Query(constants.TopicsParam); list != "" {
+		if topics, err = events.ParseTopics(strings.Split(list, ",")); err != nil {
+			return nil, err
+		}
+	}
+
+	var lastEventID uint64
+	last := c.GetHeader(constants.LastEventIDHeader)
+	if last == "" {
+		last = c.Query(constants.LastEventIDParam)
+	}
+	if last != "" {
+		if lastEventID, err = strconv.ParseUint(last, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid last event id %q", last)
+		}
+	}
+
+	return h.bus.Subscribe(user.ID, topics, lastEventID, constants.StreamBufferSize), nil
+}
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+func TestEventsHandler_Stream(t *testing.T) {
+	bus := events.NewBus(10)
+	eventsHandler := handlers.NewEventsHandler(bus, "")
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Replays missed events", func(t *testing.T) {
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, gin.H{"name": "Rex"}))
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, gin.H{"name": "Luna"}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequestWithContext(ctx, "GET", "/events?topics=animal.created", nil)
+		c.Request.Header.Set("Last-Event-ID", "1")
+
+		done := make(chan struct{})
+		go func() {
+			eventsHandler.Stream(c)
+			close(done)
+		}()
+		cancel()
+		<-done
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "id: 2\nevent: animal.created\ndata: {\"name\":\"Luna\"}\n\n")
+		assert.NotContains(t, w.Body.String(), "Rex")
+	})
+
+	t.Run("Unknown topic", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("GET", "/events?topics=everything", nil)
+
+		eventsHandler.Stream(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Streams live events", func(t *testing.T) {
+		engine := gin.New()
+		engine.GET("/events", func(c *gin.Context) { c.Set("user", userMock) }, eventsHandler.Stream)
+		server := httptest.NewServer(engine)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/events?topics=notification")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer resp.Body.Close()
+		body := bufio.NewReader(resp.Body)
+
+		// The retry hint is written once the handler is subscribed.
+		line, err := body.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, "retry: 3000\n", line)
+
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, gin.H{"name": "Max"}))
+		assert.NoError(t, bus.Publish(events.TopicNotification, []uuid.UUID{uuid.New()}, gin.H{"title": "Not yours"}))
+		assert.NoError(t, bus.Publish(events.TopicNotification, []uuid.UUID{userMock.ID}, gin.H{"title": "Hi"}))
+
+		var received []string
+		for len(received) < 3 {
+			line, err := body.ReadString('\n')
+			if !assert.NoError(t, err) {
+				return
+			}
+			if line = strings.TrimSpace(line); line != "" {
+				received = append(received, line)
+			}
+		}
+		assert.Equal(t, []string{"id: 5", "event: notification", `data: {"title":"Hi"}`}, received)
+	})
+}
+
+func TestEventsHandler_StreamWebSocket(t *testing.T) {
+	bus := events.NewBus(10)
+	eventsHandler := handlers.NewEventsHandler(bus, "https://findyourpet.example")
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	engine := gin.New()
+	engine.GET("/events/ws", func(c *gin.Context) { c.Set("user", userMock) }, eventsHandler.StreamWebSocket)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+	location := "ws" + strings.TrimPrefix(server.URL, "http") + "/events/ws?topics=notification"
+
+	t.Run("Public origin", func(t *testing.T) {
+		ws, err := websocket.Dial(location, "", "https://findyourpet.example")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer ws.Close()
+	})
+
+	t.Run("Foreign origin", func(t *testing.T) {
+		_, err := websocket.Dial(location, "", "https://attacker.example")
+		assert.Error(t, err)
+	})
+}
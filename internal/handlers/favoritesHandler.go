@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/favorites"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type FavoritesHandler struct {
+	store   favorites.FavoriteStoreI
+	animals services.AnimalServiceI
+}
+
+func NewFavoritesHandler(store favorites.FavoriteStoreI, animals services.AnimalServiceI) *FavoritesHandler {
+	return &FavoritesHandler{store: store, animals: animals}
+}
+
+func (h *FavoritesHandler) AddFavorite(c *gin.Context) {
+	animalID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid animal id"})
+		return
+	}
+
+	var body struct {
+		Note string `json:"note" binding:"max=400"`
+	}
+	// The note is optional, so is the request body.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			log.Info().Err(err).Send()
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	favorite := models.Favorite{UserID: user.ID, AnimalID: uint(animalID), Note: body.Note}
+	created, err := h.store.AddFavorite(&favorite)
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Animal not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant add favorite")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	// Editing the note of a favorite is not another like.
+	if created {
+		h.animals.NotifyLiked(favorite.AnimalID)
+	}
+
+	c.JSON(http.StatusOK, models.ToFavoriteJSON(favorite))
+}
+
+func (h *FavoritesHandler) RemoveFavorite(c *gin.Context) {
+	animalID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid animal id"})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store.RemoveFavorite(user.ID, uint(animalID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Favorite not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant remove favorite")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
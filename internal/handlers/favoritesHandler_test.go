@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFavoritesHandler_AddFavorite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFavoriteStore := mocks.NewMockFavoriteStoreI(ctrl)
+	mockAnimalService := mocks.NewMockAnimalServiceI(ctrl)
+	favoritesHandler := handlers.NewFavoritesHandler(mockFavoriteStore, mockAnimalService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful AddFavorite with note", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		body, _ := json.Marshal(gin.H{"note": "ask about the vet visit"})
+		c.Request, _ = http.NewRequest("POST", "/user/likes/7", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+		mockFavoriteStore.EXPECT().
+			AddFavorite(&models.Favorite{UserID: userMock.ID, AnimalID: 7, Note: "ask about the vet visit"}).
+			Return(true, nil)
+		mockAnimalService.EXPECT().NotifyLiked(uint(7))
+
+		favoritesHandler.AddFavorite(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Note of an existing favorite", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		body, _ := json.Marshal(gin.H{"note": "visit on Saturday"})
+		c.Request, _ = http.NewRequest("POST", "/user/likes/7", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+		// The owner already heard about this like.
+		mockFavoriteStore.EXPECT().
+			AddFavorite(&models.Favorite{UserID: userMock.ID, AnimalID: 7, Note: "visit on Saturday"}).
+			Return(false, nil)
+
+		favoritesHandler.AddFavorite(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Successful AddFavorite without body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		c.Request, _ = http.NewRequest("POST", "/user/likes/7", nil)
+		c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+		mockFavoriteStore.EXPECT().
+			AddFavorite(&models.Favorite{UserID: userMock.ID, AnimalID: 7}).
+			Return(true, nil)
+		mockAnimalService.EXPECT().NotifyLiked(uint(7))
+
+		favoritesHandler.AddFavorite(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unknown animal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		c.Request, _ = http.NewRequest("POST", "/user/likes/404", nil)
+		c.Params = gin.Params{{Key: "id", Value: "404"}}
+
+		mockFavoriteStore.EXPECT().AddFavorite(gomock.Any()).Return(false, gorm.ErrForeignKeyViolated)
+
+		favoritesHandler.AddFavorite(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid animal id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		c.Request, _ = http.NewRequest("POST", "/user/likes/abc", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		favoritesHandler.AddFavorite(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFavoritesHandler_RemoveFavorite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFavoriteStore := mocks.NewMockFavoriteStoreI(ctrl)
+	mockAnimalService := mocks.NewMockAnimalServiceI(ctrl)
+	favoritesHandler := handlers.NewFavoritesHandler(mockFavoriteStore, mockAnimalService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful RemoveFavorite", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		c.Request, _ = http.NewRequest("DELETE", "/user/likes/7", nil)
+		c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+		mockFavoriteStore.EXPECT().RemoveFavorite(userMock.ID, uint(7)).Return(nil)
+
+		favoritesHandler.RemoveFavorite(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Missing favorite", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+
+		c.Request, _ = http.NewRequest("DELETE", "/user/likes/7", nil)
+		c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+		mockFavoriteStore.EXPECT().RemoveFavorite(userMock.ID, uint(7)).Return(gorm.ErrRecordNotFound)
+
+		favoritesHandler.RemoveFavorite(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// FilesHandler serves the files of the local storage backend and accepts
+// the uploads presigned for it.
+type FilesHandler struct {
+	store *storage.LocalStore
+}
+
+func NewFilesHandler(store *storage.LocalStore) *FilesHandler {
+	return &FilesHandler{store: store}
+}
+
+func (h *FilesHandler) GetFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if _, err := h.store.Head(key); err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	name, _ := h.store.Path(key)
+
+	c.Header("Content-Type", storage.ContentType(key))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.File(name)
+}
+
+// PutFile stores a presigned upload. The signature stands in for
+// authentication, and the upload must have the signed type and size.
+func (h *FilesHandler) PutFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	contentType := c.GetHeader("Content-Type")
+	size, err := h.store.VerifyPut(key, contentType, c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if c.Request.ContentLength != size {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content-Length does not match the presigned size"})
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, size)
+	if _, err := h.store.Put(key, body, contentType); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.Info().Err(err).Msg("Cant store file")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusOK)
+}
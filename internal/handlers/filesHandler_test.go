@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesHandler(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost/files")
+	assert.NoError(t, err)
+	filesHandler := handlers.NewFilesHandler(store)
+	e := gin.New()
+	e.GET("/files/*key", filesHandler.GetFile)
+	e.PUT("/files/*key", filesHandler.PutFile)
+
+	put := func(url, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", strings.TrimPrefix(url, "http://localhost"), strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		e.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("Presigned upload is stored and served", func(t *testing.T) {
+		upload, err := store.PresignPut("animals/3/uploads/a.png", "image/png", 5, time.Minute)
+		assert.NoError(t, err)
+
+		w := put(upload.URL, "image/png", "photo")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/files/animals/3/uploads/a.png", nil)
+		e.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "photo", w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("Upload does not match the signature", func(t *testing.T) {
+		upload, err := store.PresignPut("animals/3/uploads/b.png", "image/png", 5, time.Minute)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, put(upload.URL, "text/html", "photo").Code)
+		assert.Equal(t, http.StatusBadRequest, put(upload.URL, "image/png", "larger photo").Code)
+		_, err = store.Head("animals/3/uploads/b.png")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("Missing files", func(t *testing.T) {
+		for _, path := range []string{"/files/none.png", "/files/animals", "/files/../go.mod"} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", path, nil)
+			e.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusNotFound, w.Code, path)
+		}
+	})
+}
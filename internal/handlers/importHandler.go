@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type ImportHandler struct {
+	importService services.ImportServiceI
+}
+
+func NewImportHandler(importService services.ImportServiceI) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// ImportAnimals adds the listings of a CSV or JSON Lines body to the user.
+// The format is given by the content type or the format query parameter,
+// and dryRun=true only validates the rows. Photos are given by URL.
+func (h *ImportHandler) ImportAnimals(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format, err = imports.FormatOf(c.ContentType(), "")
+	}
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"Error": err.Error()})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxImportSize)
+	report, err := h.importService.ImportAnimals(user.ID, c.Request.Body, services.ImportOptions{
+		Format:     format,
+		DryRun:     dryRun,
+		MaxRows:    constants.MaxImportRows,
+		MaxFetches: constants.MaxImportFetches,
+	})
+	if err != nil {
+		var maxBytes *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytes):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"Error": err.Error()})
+		case errors.Is(err, imports.ErrUnknownFormat):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"Error": err.Error()})
+		default:
+			log.Info().Err(err).Msg("Cant read import")
+			c.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportHandler_ImportAnimals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportService := mocks.NewMockImportServiceI(ctrl)
+	importHandler := handlers.NewImportHandler(mockImportService)
+	userMock := &models.User{ID: uuid.New(), Email: "shelter@email.com"}
+
+	newContext := func(url, contentType string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("POST", url, strings.NewReader("name\nLuna\n"))
+		c.Request.Header.Set("Content-Type", contentType)
+		return c, w
+	}
+
+	t.Run("Dry run of a CSV", func(t *testing.T) {
+		c, w := newContext("/animal/import?dryRun=true", "text/csv")
+		report := models.ImportReportJSON{DryRun: true, Rows: 1, Errors: []models.ImportRowErrorJSON{{Row: 2, Error: "invalid"}}}
+		mockImportService.EXPECT().
+			ImportAnimals(userMock.ID, gomock.Any(), services.ImportOptions{Format: imports.FormatCSV, DryRun: true, MaxRows: constants.MaxImportRows, MaxFetches: constants.MaxImportFetches}).
+			Return(report, nil)
+
+		importHandler.ImportAnimals(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body models.ImportReportJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, report, body)
+	})
+
+	t.Run("Format given by query", func(t *testing.T) {
+		c, w := newContext("/animal/import?format=jsonl", "text/plain")
+		mockImportService.EXPECT().
+			ImportAnimals(userMock.ID, gomock.Any(), services.ImportOptions{Format: imports.FormatJSONL, MaxRows: constants.MaxImportRows, MaxFetches: constants.MaxImportFetches}).
+			Return(models.ImportReportJSON{}, nil)
+
+		importHandler.ImportAnimals(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		c, w := newContext("/animal/import", "application/vnd.ms-excel")
+
+		importHandler.ImportAnimals(c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Unreadable import", func(t *testing.T) {
+		c, w := newContext("/animal/import", "text/csv")
+		mockImportService.EXPECT().ImportAnimals(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(models.ImportReportJSON{}, errors.New(`unknown column "breed"`))
+
+		importHandler.ImportAnimals(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "breed")
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type ModerationHandler struct {
+	store animals.AnimalStoreI
+}
+
+func NewModerationHandler(store animals.AnimalStoreI) *ModerationHandler {
+	return &ModerationHandler{store: store}
+}
+
+// GetQueue returns the oldest listings waiting for a moderator. The next
+// page starts after the ID of the last item.
+func (h *ModerationHandler) GetQueue(c *gin.Context) {
+	var afterID uint64
+	if after := c.Query(constants.AfterParam); after != "" {
+		var err error
+		if afterID, err = strconv.ParseUint(after, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid after"})
+			return
+		}
+	}
+
+	items, err := h.store.GetModerationItems(models.ModerationPending, uint(afterID), constants.ModerationQueueSize)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get moderation queue")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to get moderation queue"})
+		return
+	}
+	c.JSON(http.StatusOK, models.ToModerationItemJSONArray(items))
+}
+
+// ResolveItem takes the item off the queue, as resolved or dismissed.
+func (h *ModerationHandler) ResolveItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+	var body models.ModerationResolutionJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.store.ResolveModerationItem(uint(id), body.Status, user.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pending item not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant resolve moderation item")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to resolve moderation item"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
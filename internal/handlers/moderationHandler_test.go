@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestModerationHandler_GetQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	moderationHandler := handlers.NewModerationHandler(mockAnimalStore)
+
+	t.Run("Pending items", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/moderation", nil)
+
+		original := uint(3)
+		mockAnimalStore.EXPECT().
+			GetModerationItems(models.ModerationPending, uint(0), constants.ModerationQueueSize).
+			Return([]models.ModerationItem{{ID: 1, AnimalID: 9, Reason: models.ModerationDuplicatePhoto, DuplicateOfID: &original, Distance: 2, Status: models.ModerationPending}}, nil)
+
+		moderationHandler.GetQueue(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var items []models.ModerationItemJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+		assert.Len(t, items, 1)
+		assert.Equal(t, uint(9), items[0].AnimalID)
+		assert.Equal(t, &original, items[0].DuplicateOfID)
+	})
+
+	t.Run("Store failure", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/moderation", nil)
+
+		mockAnimalStore.EXPECT().GetModerationItems(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		moderationHandler.GetQueue(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Next page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/moderation?after=50", nil)
+
+		mockAnimalStore.EXPECT().GetModerationItems(models.ModerationPending, uint(50), constants.ModerationQueueSize).Return(nil, nil)
+
+		moderationHandler.GetQueue(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[]", w.Body.String())
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/moderation?after=last", nil)
+
+		moderationHandler.GetQueue(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestModerationHandler_ResolveItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	moderationHandler := handlers.NewModerationHandler(mockAnimalStore)
+	moderator := &models.User{ID: uuid.New(), Role: models.RoleAdmin}
+
+	resolve := func(id, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", moderator)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request, _ = http.NewRequest("POST", "/moderation/"+id+"/resolve", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		moderationHandler.ResolveItem(c)
+		return w
+	}
+
+	t.Run("Dismissed", func(t *testing.T) {
+		mockAnimalStore.EXPECT().ResolveModerationItem(uint(1), models.ModerationDismissed, moderator.ID).Return(nil)
+
+		w := resolve("1", `{"status":"dismissed"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Already closed", func(t *testing.T) {
+		mockAnimalStore.EXPECT().ResolveModerationItem(uint(1), models.ModerationResolved, moderator.ID).Return(gorm.ErrRecordNotFound)
+
+		w := resolve("1", `{"status":"resolved"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		w := resolve("1", `{"status":"pending"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
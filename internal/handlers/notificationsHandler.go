@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type NotificationsHandler struct {
+	service services.NotificationServiceI
+}
+
+func NewNotificationsHandler(service services.NotificationServiceI) *NotificationsHandler {
+	return &NotificationsHandler{service: service}
+}
+
+func (h *NotificationsHandler) GetNotifications(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	notifications, unread, err := h.service.GetNotifications(user.ID, c)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get notifications")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NotificationsPage{
+		PaginatedContent: models.PaginatedContent[models.NotificationJSON]{
+			Data:       models.ToNotificationJSONArray(notifications),
+			Page:       c.GetInt("page"),
+			PageSize:   c.GetInt("pageSize"),
+			TotalPages: c.GetInt("totalPages"),
+			Cursor:     c.GetString("cursor"),
+			NextCursor: c.GetString("nextCursor"),
+		},
+		Unread: unread,
+	})
+}
+
+func (h *NotificationsHandler) MarkAsRead(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkAsRead(user.ID, c.Param("id")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant mark notification as read")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *NotificationsHandler) MarkAllAsRead(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkAllAsRead(user.ID); err != nil {
+		log.Info().Err(err).Msg("Cant mark notifications as read")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
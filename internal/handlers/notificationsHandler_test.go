@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestNotificationsHandler_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockNotificationServiceI(ctrl)
+	notificationsHandler := handlers.NewNotificationsHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful GetNotifications", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("GET", "/notifications", nil)
+
+		notifications := []models.Notification{
+			{ID: 2, UserID: userMock.ID, Type: models.NotificationTypeSavedSearch, Title: "New matches"},
+		}
+		mockService.EXPECT().GetNotifications(userMock.ID, c).Return(notifications, int64(3), nil)
+
+		notificationsHandler.GetNotifications(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.NotificationsPage
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(3), response.Unread)
+		assert.Len(t, response.Data, 1)
+		assert.False(t, response.Data[0].Read)
+	})
+}
+
+func TestNotificationsHandler_MarkAsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockNotificationServiceI(ctrl)
+	notificationsHandler := handlers.NewNotificationsHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful MarkAsRead", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "2"}}
+		c.Request, _ = http.NewRequest("POST", "/notifications/2/read", nil)
+
+		mockService.EXPECT().MarkAsRead(userMock.ID, "2").Return(nil)
+
+		notificationsHandler.MarkAsRead(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Notification of another user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "9"}}
+		c.Request, _ = http.NewRequest("POST", "/notifications/9/read", nil)
+
+		mockService.EXPECT().MarkAsRead(userMock.ID, "9").Return(gorm.ErrRecordNotFound)
+
+		notificationsHandler.MarkAsRead(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
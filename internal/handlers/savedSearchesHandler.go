@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type SavedSearchesHandler struct {
+	service services.SavedSearchServiceI
+}
+
+func NewSavedSearchesHandler(service services.SavedSearchServiceI) *SavedSearchesHandler {
+	return &SavedSearchesHandler{service: service}
+}
+
+func (h *SavedSearchesHandler) CreateSavedSearch(c *gin.Context) {
+	var body models.SavedSearchJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	search, err := h.service.CreateSavedSearch(user.ID, &body)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedFilter) || errors.Is(err, services.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Info().Err(err).Msg("Cant create saved search")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToSavedSearchJSON(search))
+}
+
+func (h *SavedSearchesHandler) GetSavedSearches(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	searches, err := h.service.GetSavedSearches(user.ID)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get saved searches")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToSavedSearchJSONArray(searches))
+}
+
+func (h *SavedSearchesHandler) DeleteSavedSearch(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteSavedSearch(user.ID, c.Param("id")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant delete saved search")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// confirmUnsubscribePage posts back to the unsubscribe link it is shown at.
+const confirmUnsubscribePage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+<form method="post">
+<p>Stop receiving emails for this saved search?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body>
+</html>
+`
+
+// ConfirmUnsubscribe shows the unsubscribe link of digest emails. Opening
+// the link changes nothing, as mail scanners and link previews open it too;
+// the page asks the user to confirm.
+func (h *SavedSearchesHandler) ConfirmUnsubscribe(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(confirmUnsubscribePage))
+}
+
+// Unsubscribe handles the confirmation of the unsubscribe link of digest
+// emails, so it does not require the user to be logged in.
+func (h *SavedSearchesHandler) Unsubscribe(c *gin.Context) {
+	if err := h.service.Unsubscribe(c.Param("token")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
+			return
+		}
+		log.Info().Err(err).Msg("Cant unsubscribe from saved search")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.String(http.StatusOK, "You will no longer receive emails for this saved search.")
+}
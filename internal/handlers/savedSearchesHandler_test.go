@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSavedSearchesHandler_CreateSavedSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSavedSearchServiceI(ctrl)
+	savedSearchesHandler := handlers.NewSavedSearchesHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	body := models.SavedSearchJSON{Name: "Cats", Filters: map[string]string{"q": "cat"}, NotifyInApp: true}
+	reqBody, _ := json.Marshal(body)
+
+	t.Run("Successful CreateSavedSearch", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("POST", "/searches", bytes.NewBuffer(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		saved := models.SavedSearch{UserID: userMock.ID, Name: "Cats", Query: "q=cat", NotifyInApp: true}
+		mockService.EXPECT().CreateSavedSearch(userMock.ID, &body).Return(saved, nil)
+
+		savedSearchesHandler.CreateSavedSearch(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SavedSearchJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, map[string]string{"q": "cat"}, response.Filters)
+	})
+
+	t.Run("Unsupported filter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("POST", "/searches", bytes.NewBuffer(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		mockService.EXPECT().CreateSavedSearch(userMock.ID, &body).
+			Return(models.SavedSearch{}, fmt.Errorf("%w %q", services.ErrUnsupportedFilter, "sort"))
+
+		savedSearchesHandler.CreateSavedSearch(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unsupported filter")
+	})
+}
+
+func TestSavedSearchesHandler_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockSavedSearchServiceI(ctrl)
+	savedSearchesHandler := handlers.NewSavedSearchesHandler(mockService)
+
+	t.Run("Opening the link only asks to confirm", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "token", Value: "abc"}}
+		c.Request, _ = http.NewRequest("GET", "/searches/unsubscribe/abc", nil)
+
+		savedSearchesHandler.ConfirmUnsubscribe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<form method="post">`)
+	})
+
+	t.Run("Successful Unsubscribe", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "token", Value: "abc"}}
+		c.Request, _ = http.NewRequest("POST", "/searches/unsubscribe/abc", nil)
+
+		mockService.EXPECT().Unsubscribe("abc").Return(nil)
+
+		savedSearchesHandler.Unsubscribe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "token", Value: "nope"}}
+		c.Request, _ = http.NewRequest("POST", "/searches/unsubscribe/nope", nil)
+
+		mockService.EXPECT().Unsubscribe("nope").Return(gorm.ErrRecordNotFound)
+
+		savedSearchesHandler.Unsubscribe(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/swipes"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type SwipesHandler struct {
+	store swipes.SwipeStoreI
+}
+
+func NewSwipesHandler(store swipes.SwipeStoreI) *SwipesHandler {
+	return &SwipesHandler{store: store}
+}
+
+func (h *SwipesHandler) UndoLastSwipe(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.store.UndoLastSwipe(user.ID, constants.SwipeUndoWindow, swipeClient(c))
+	if err != nil {
+		if errors.Is(err, swipes.ErrNothingToUndo) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		log.Info().Err(err).Msg("Cant undo swipe")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToSwipeEventJSON(event))
+}
+
+func (h *SwipesHandler) GetSwipeHistory(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.store.GetSwipeHistory(user.ID, c)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get swipe history")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedContent[models.SwipeEventJSON]{
+		Data:       models.ToSwipeEventJSONArray(events),
+		Page:       c.GetInt("page"),
+		PageSize:   c.GetInt("pageSize"),
+		TotalPages: c.GetInt("totalPages"),
+		Cursor:     c.GetString("cursor"),
+		NextCursor: c.GetString("nextCursor"),
+	})
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/swipes"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwipesHandler_UndoLastSwipe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwipeStore := mocks.NewMockSwipeStoreI(ctrl)
+	swipesHandler := handlers.NewSwipesHandler(mockSwipeStore)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful UndoLastSwipe", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("POST", "/swipes/undo", nil)
+		c.Request.Header.Set("X-Client", "android/3.1.0")
+
+		undone := models.SwipeEvent{ID: 12, UserID: userMock.ID, AnimalID: 3, Action: models.SwipeActionPass}
+		mockSwipeStore.EXPECT().
+			UndoLastSwipe(userMock.ID, constants.SwipeUndoWindow, "android/3.1.0").
+			Return(undone, nil)
+
+		swipesHandler.UndoLastSwipe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SwipeEventJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.ToSwipeEventJSON(undone), response)
+	})
+
+	t.Run("Nothing to undo", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("POST", "/swipes/undo", nil)
+
+		mockSwipeStore.EXPECT().
+			UndoLastSwipe(userMock.ID, constants.SwipeUndoWindow, "").
+			Return(models.SwipeEvent{}, swipes.ErrNothingToUndo)
+
+		swipesHandler.UndoLastSwipe(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestSwipesHandler_GetSwipeHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSwipeStore := mocks.NewMockSwipeStoreI(ctrl)
+	swipesHandler := handlers.NewSwipesHandler(mockSwipeStore)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful GetSwipeHistory", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Request, _ = http.NewRequest("GET", "/user/swipes?cursor=", nil)
+
+		events := []models.SwipeEvent{
+			{ID: 2, AnimalID: 5, Action: models.SwipeActionLike},
+			{ID: 1, AnimalID: 4, Action: models.SwipeActionPass},
+		}
+		mockSwipeStore.EXPECT().GetSwipeHistory(userMock.ID, c).DoAndReturn(
+			func(_ uuid.UUID, c *gin.Context) ([]models.SwipeEvent, error) {
+				c.Set("pageSize", 2)
+				c.Set("nextCursor", "next")
+				return events, nil
+			})
+
+		swipesHandler.GetSwipeHistory(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.PaginatedContent[models.SwipeEventJSON]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.ToSwipeEventJSONArray(events), response.Data)
+		assert.Equal(t, "next", response.NextCursor)
+	})
+}
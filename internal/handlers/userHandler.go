@@ -5,9 +5,11 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/auth"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -27,10 +29,11 @@ type UserHandlerInterface interface {
 type UserHandler struct {
 	authService auth.AuthServiceI
 	store       users.UserStoreI
+	emails      *mail.Renderer
 }
 
-func NewUserHandler(auth auth.AuthServiceI, store users.UserStoreI) *UserHandler {
-	return &UserHandler{authService: auth, store: store}
+func NewUserHandler(auth auth.AuthServiceI, store users.UserStoreI, emails *mail.Renderer) *UserHandler {
+	return &UserHandler{authService: auth, store: store, emails: emails}
 }
 
 func (h *UserHandler) SignUp(c *gin.Context) {
@@ -49,12 +52,26 @@ func (h *UserHandler) SignUp(c *gin.Context) {
 		return
 	}
 
+	locale := body.Locale
+	if locale == "" {
+		locale = mail.DefaultLocale
+	}
 	user := models.User{
 		Email:        body.Email,
 		Password:     string(hash),
+		Locale:       locale,
 		UserSettings: constants.DefaultUserSettings,
 	}
-	err = h.store.Create(&user)
+
+	var emails []models.OutboxEmail
+	welcome, err := h.emails.Render(mail.TemplateWelcome, locale, gin.H{"Email": user.Email})
+	if err != nil {
+		log.Err(err).Msg("Failed to render welcome email")
+	} else {
+		welcome.To = user.Email
+		emails = append(emails, outbox.NewEmail(mail.TemplateWelcome, welcome))
+	}
+	err = h.store.Create(&user, emails...)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
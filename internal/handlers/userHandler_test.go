@@ -10,6 +10,7 @@ import (
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -23,7 +24,9 @@ func TestUserHandler_SignUp(t *testing.T) {
 
 	mockAuthService := mocks.NewMockAuthServiceI(ctrl)
 	mockUserStore := mocks.NewMockUserStoreI(ctrl)
-	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore)
+	emails, err := mail.NewRenderer()
+	assert.NoError(t, err)
+	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore, emails)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -33,6 +36,7 @@ func TestUserHandler_SignUp(t *testing.T) {
 		expectedUser := models.User{
 			Email:        "test@example.com",
 			Password:     hashedPassword,
+			Locale:       "en",
 			UserSettings: constants.DefaultUserSettings,
 		}
 
@@ -41,8 +45,14 @@ func TestUserHandler_SignUp(t *testing.T) {
 			Return(hashedPassword, nil)
 
 		mockUserStore.EXPECT().
-			Create(&expectedUser).
-			Return(nil)
+			Create(&expectedUser, gomock.Any()).
+			DoAndReturn(func(user *models.User, emails ...models.OutboxEmail) error {
+				assert.Len(t, emails, 1)
+				assert.Equal(t, "test@example.com", emails[0].Recipient)
+				assert.Equal(t, "Welcome to FindYourPet", emails[0].Subject)
+				assert.Equal(t, models.EmailPending, emails[0].Status)
+				return nil
+			})
 
 		reqBody := models.UserSingupJSON{
 			Email:    "test@example.com",
@@ -59,6 +69,30 @@ func TestUserHandler_SignUp(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("Welcome email in the user's locale", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		mockAuthService.EXPECT().
+			GenerateHashFromPassword("password").
+			Return("hashed_password", nil)
+
+		mockUserStore.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(user *models.User, emails ...models.OutboxEmail) error {
+				assert.Equal(t, "uk", user.Locale)
+				assert.Equal(t, "Вітаємо у FindYourPet", emails[0].Subject)
+				return nil
+			})
+
+		userJSON, _ := json.Marshal(models.UserSingupJSON{Email: "test@example.com", Password: "password", Locale: "uk"})
+		c.Request, _ = http.NewRequest("POST", "/signup", bytes.NewBuffer(userJSON))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		userHandler.SignUp(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 }
 
 func TestUserHandler_LogIn(t *testing.T) {
@@ -67,7 +101,7 @@ func TestUserHandler_LogIn(t *testing.T) {
 
 	mockAuthService := mocks.NewMockAuthServiceI(ctrl)
 	mockUserStore := mocks.NewMockUserStoreI(ctrl)
-	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore)
+	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -103,7 +137,7 @@ func TestUserHandler_SetUserSettings(t *testing.T) {
 
 	mockAuthService := mocks.NewMockAuthServiceI(ctrl)
 	mockUserStore := mocks.NewMockUserStoreI(ctrl)
-	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore)
+	userHandler := handlers.NewUserHandler(mockAuthService, mockUserStore, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -140,7 +174,7 @@ func TestUserHandler_GetUserSettings(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserStore := mocks.NewMockUserStoreI(ctrl)
-	userHandler := handlers.NewUserHandler(nil, mockUserStore)
+	userHandler := handlers.NewUserHandler(nil, mockUserStore, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
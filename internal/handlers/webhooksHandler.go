@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type WebhooksHandler struct {
+	service services.WebhookServiceI
+}
+
+func NewWebhooksHandler(service services.WebhookServiceI) *WebhooksHandler {
+	return &WebhooksHandler{service: service}
+}
+
+// CreateWebhook responds with the signing secret, which is not shown again.
+func (h *WebhooksHandler) CreateWebhook(c *gin.Context) {
+	var body models.WebhookJSON
+	if err := c.ShouldBindJSON(&body); err != nil {
+		log.Info().Err(err).Send()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(user.ID, &body)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant create webhook")
+		if errors.Is(err, netguard.ErrPrivateAddress) || errors.Is(err, netguard.ErrUnsupportedURL) || errors.Is(err, services.ErrUnknownEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	response := models.ToWebhookJSON(webhook)
+	response.Secret = webhook.Secret
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhooksHandler) GetWebhooks(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	webhooks, err := h.service.GetWebhooks(user.ID)
+	if err != nil {
+		log.Info().Err(err).Msg("Cant get webhooks")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToWebhookJSONArray(webhooks))
+}
+
+func (h *WebhooksHandler) DeleteWebhook(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(user.ID, c.Param("id")); err != nil {
+		webhookError(c, err, "Cant delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *WebhooksHandler) GetDeliveries(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(user.ID, c.Param("id"), c)
+	if err != nil {
+		webhookError(c, err, "Cant get webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedContent[models.WebhookDeliveryJSON]{
+		Data:       models.ToWebhookDeliveryJSONArray(deliveries),
+		Page:       c.GetInt("page"),
+		PageSize:   c.GetInt("pageSize"),
+		TotalPages: c.GetInt("totalPages"),
+		Cursor:     c.GetString("cursor"),
+		NextCursor: c.GetString("nextCursor"),
+	})
+}
+
+// Ping sends a test event to the webhook and responds with the delivery.
+func (h *WebhooksHandler) Ping(c *gin.Context) {
+	user, err := getUserDataFromContext(c)
+	if err != nil {
+		log.Info().Err(err).Send()
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.service.Ping(user.ID, c.Param("id"))
+	if err != nil {
+		webhookError(c, err, "Cant ping webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToWebhookDeliveryJSON(delivery))
+}
+
+func webhookError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return
+	}
+	log.Info().Err(err).Msg(msg)
+	c.Status(http.StatusBadRequest)
+}
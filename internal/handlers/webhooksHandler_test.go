@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestWebhooksHandler_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookServiceI(ctrl)
+	webhooksHandler := handlers.NewWebhooksHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful CreateWebhook", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		body := []byte(`{"url":"https://shelter.example/hooks","events":["animal.liked"]}`)
+		c.Request, _ = http.NewRequest("POST", "/webhooks", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		created := models.Webhook{URL: "https://shelter.example/hooks", Events: "animal.liked", Secret: "s3cret", Active: true}
+		mockService.EXPECT().CreateWebhook(userMock.ID, gomock.Any()).Return(created, nil)
+
+		webhooksHandler.CreateWebhook(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.WebhookJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "s3cret", response.Secret)
+		assert.Equal(t, []string{"animal.liked"}, response.Events)
+	})
+
+	t.Run("Unknown event", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		body := []byte(`{"url":"https://shelter.example/hooks","events":["application.submitted"]}`)
+		c.Request, _ = http.NewRequest("POST", "/webhooks", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		webhooksHandler.CreateWebhook(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Internal address", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		body := []byte(`{"url":"http://169.254.169.254/latest","events":["animal.liked"]}`)
+		c.Request, _ = http.NewRequest("POST", "/webhooks", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		mockService.EXPECT().CreateWebhook(userMock.ID, gomock.Any()).Return(models.Webhook{}, netguard.ErrPrivateAddress)
+
+		webhooksHandler.CreateWebhook(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), netguard.ErrPrivateAddress.Error())
+	})
+}
+
+func TestWebhooksHandler_GetWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookServiceI(ctrl)
+	webhooksHandler := handlers.NewWebhooksHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("user", userMock)
+	c.Request, _ = http.NewRequest("GET", "/webhooks", nil)
+
+	mockService.EXPECT().GetWebhooks(userMock.ID).Return([]models.Webhook{{URL: "https://shelter.example/hooks", Secret: "s3cret"}}, nil)
+
+	webhooksHandler.GetWebhooks(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+}
+
+func TestWebhooksHandler_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookServiceI(ctrl)
+	webhooksHandler := handlers.NewWebhooksHandler(mockService)
+	userMock := &models.User{ID: uuid.New(), Email: "test123@email.com"}
+
+	t.Run("Successful Ping", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		c.Request, _ = http.NewRequest("POST", "/webhooks/3/ping", nil)
+
+		delivery := models.WebhookDelivery{ID: 10, EventType: "ping", Status: models.WebhookDeliverySucceeded, Attempts: 1, LastStatusCode: 200}
+		mockService.EXPECT().Ping(userMock.ID, "3").Return(delivery, nil)
+
+		webhooksHandler.Ping(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.WebhookDeliveryJSON
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.WebhookDeliverySucceeded, response.Status)
+	})
+
+	t.Run("Webhook of another user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user", userMock)
+		c.Params = gin.Params{{Key: "id", Value: "9"}}
+		c.Request, _ = http.NewRequest("POST", "/webhooks/9/ping", nil)
+
+		mockService.EXPECT().Ping(userMock.ID, "9").Return(models.WebhookDelivery{}, gorm.ErrRecordNotFound)
+
+		webhooksHandler.Ping(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -4,9 +4,15 @@ import (
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/handlers"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/favorites"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/swipes"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/auth"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/middleware"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -18,9 +24,22 @@ type Router struct {
 	userStore     *users.UserStore
 	animalsStore  *animals.AnimalStore
 	animalService *services.AnimalService
+	importService *services.ImportService
+	emails        *mail.Renderer
+	// files is nil unless files are stored on the local disk.
+	files *storage.LocalStore
+
+	savedSearchService  *services.SavedSearchService
+	notificationService *services.NotificationService
+	conversationService *services.ConversationService
+	webhookService      *services.WebhookService
+	eventBus            *events.Bus
+	// publicURL is where the API is served, WebSocket origins are checked
+	// against it.
+	publicURL string
 }
 
-func NewRouter(db *gorm.DB, userStore *users.UserStore, animalsStore *animals.AnimalStore, animalService *services.AnimalService) *Router {
+func NewRouter(db *gorm.DB, userStore *users.UserStore, animalsStore *animals.AnimalStore, animalService *services.AnimalService, importService *services.ImportService, savedSearchService *services.SavedSearchService, notificationService *services.NotificationService, conversationService *services.ConversationService, webhookService *services.WebhookService, eventBus *events.Bus, emails *mail.Renderer, files *storage.LocalStore, publicURL string) *Router {
 	authService := auth.NewAuthService()
 	return &Router{
 		db:            db,
@@ -28,17 +47,35 @@ func NewRouter(db *gorm.DB, userStore *users.UserStore, animalsStore *animals.An
 		userStore:     userStore,
 		animalsStore:  animalsStore,
 		animalService: animalService,
+		importService: importService,
+		emails:        emails,
+		files:         files,
+
+		savedSearchService:  savedSearchService,
+		notificationService: notificationService,
+		conversationService: conversationService,
+		webhookService:      webhookService,
+		eventBus:            eventBus,
+		publicURL:           publicURL,
 	}
 }
 
 func (r *Router) SetupAPIs(e *gin.Engine) {
-	e.MaxMultipartMemory = 7 << 20 // 7 MiB
 	r.setupUsers(e)
 	r.setupAnimals(e)
+	r.setupFavorites(e)
+	r.setupSwipes(e)
+	r.setupSavedSearches(e)
+	r.setupNotifications(e)
+	r.setupEvents(e)
+	r.setupConversations(e)
+	r.setupWebhooks(e)
+	r.setupFiles(e)
+	r.setupModeration(e)
 }
 
 func (r *Router) setupUsers(e *gin.Engine) {
-	userController := handlers.NewUserHandler(r.authService, r.userStore)
+	userController := handlers.NewUserHandler(r.authService, r.userStore, r.emails)
 	e.POST("/singup", userController.SignUp)
 	e.POST("/login", userController.LogIn)
 	e.GET("/user", middleware.RequireAuth(r.userStore), userController.GetUser)
@@ -50,8 +87,86 @@ func (r *Router) setupAnimals(e *gin.Engine) {
 	animalsHandler := handlers.NewAnimalsHandler(r.animalService)
 	e.POST("/animal", middleware.RequireAuth(r.userStore), animalsHandler.AddAnimal)
 	e.GET("/animal/:id", middleware.RequireAuth(r.userStore), animalsHandler.GetAnimalByID)
+	e.PATCH("/animal/:id", middleware.RequireAuth(r.userStore), animalsHandler.UpdateAnimal)
+	e.POST("/animal/:id/photos/uploads", middleware.RequireAuth(r.userStore), animalsHandler.PresignPhotoUploads)
+	e.POST("/animal/:id/photos", middleware.RequireAuth(r.userStore), animalsHandler.AddPhotos)
+	e.PUT("/animal/:id/photos/order", middleware.RequireAuth(r.userStore), animalsHandler.ReorderPhotos)
+	e.DELETE("/animal/:id/photos/:photoId", middleware.RequireAuth(r.userStore), animalsHandler.DeletePhoto)
+	e.PUT("/animal/:id/cover", middleware.RequireAuth(r.userStore), animalsHandler.SetCover)
 	e.PUT("/markasseen/:id", middleware.RequireAuth(r.userStore), animalsHandler.MarkAsSeen)
 	e.GET("/animal", middleware.RequireAuth(r.userStore), animalsHandler.GetAnimals)
 	e.GET("/animal/all", middleware.RequireAuth(r.userStore), animalsHandler.GetAllAnimals)
 	e.GET("/user/likes", middleware.RequireAuth(r.userStore), animalsHandler.GetLikedAnimals)
+
+	importHandler := handlers.NewImportHandler(r.importService)
+	e.POST("/animal/import", middleware.RequireAuth(r.userStore), importHandler.ImportAnimals)
+}
+
+func (r *Router) setupFavorites(e *gin.Engine) {
+	favoritesHandler := handlers.NewFavoritesHandler(favorites.NewFavoriteStore(r.db), r.animalService)
+	e.POST("/user/likes/:id", middleware.RequireAuth(r.userStore), favoritesHandler.AddFavorite)
+	e.DELETE("/user/likes/:id", middleware.RequireAuth(r.userStore), favoritesHandler.RemoveFavorite)
+}
+
+func (r *Router) setupSwipes(e *gin.Engine) {
+	swipesHandler := handlers.NewSwipesHandler(swipes.NewSwipeStore(r.db))
+	e.POST("/swipes/undo", middleware.RequireAuth(r.userStore), swipesHandler.UndoLastSwipe)
+	e.GET("/user/swipes", middleware.RequireAuth(r.userStore), swipesHandler.GetSwipeHistory)
+}
+
+func (r *Router) setupSavedSearches(e *gin.Engine) {
+	savedSearchesHandler := handlers.NewSavedSearchesHandler(r.savedSearchService)
+	e.POST("/searches", middleware.RequireAuth(r.userStore), savedSearchesHandler.CreateSavedSearch)
+	e.GET("/searches", middleware.RequireAuth(r.userStore), savedSearchesHandler.GetSavedSearches)
+	e.DELETE("/searches/:id", middleware.RequireAuth(r.userStore), savedSearchesHandler.DeleteSavedSearch)
+	e.GET("/searches/unsubscribe/:token", savedSearchesHandler.ConfirmUnsubscribe)
+	e.POST("/searches/unsubscribe/:token", savedSearchesHandler.Unsubscribe)
+}
+
+func (r *Router) setupNotifications(e *gin.Engine) {
+	notificationsHandler := handlers.NewNotificationsHandler(r.notificationService)
+	e.GET("/notifications", middleware.RequireAuth(r.userStore), notificationsHandler.GetNotifications)
+	e.POST("/notifications/read", middleware.RequireAuth(r.userStore), notificationsHandler.MarkAllAsRead)
+	e.POST("/notifications/:id/read", middleware.RequireAuth(r.userStore), notificationsHandler.MarkAsRead)
+}
+
+func (r *Router) setupEvents(e *gin.Engine) {
+	eventsHandler := handlers.NewEventsHandler(r.eventBus, r.publicURL)
+	e.GET("/events", middleware.RequireAuth(r.userStore), eventsHandler.Stream)
+	e.GET("/events/ws", middleware.RequireAuth(r.userStore), eventsHandler.StreamWebSocket)
+}
+
+func (r *Router) setupConversations(e *gin.Engine) {
+	conversationsHandler := handlers.NewConversationsHandler(r.conversationService)
+	e.POST("/animal/:id/conversations", middleware.RequireAuth(r.userStore), conversationsHandler.StartConversation)
+	e.GET("/conversations", middleware.RequireAuth(r.userStore), conversationsHandler.GetConversations)
+	e.GET("/conversations/:id/messages", middleware.RequireAuth(r.userStore), conversationsHandler.GetMessages)
+	e.POST("/conversations/:id/messages", middleware.RequireAuth(r.userStore), conversationsHandler.SendMessage)
+	e.POST("/conversations/:id/read", middleware.RequireAuth(r.userStore), conversationsHandler.MarkAsRead)
+	e.POST("/conversations/:id/block", middleware.RequireAuth(r.userStore), conversationsHandler.BlockParticipant)
+	e.POST("/conversations/:id/report", middleware.RequireAuth(r.userStore), conversationsHandler.ReportParticipant)
+}
+
+func (r *Router) setupWebhooks(e *gin.Engine) {
+	webhooksHandler := handlers.NewWebhooksHandler(r.webhookService)
+	e.POST("/webhooks", middleware.RequireAuth(r.userStore), webhooksHandler.CreateWebhook)
+	e.GET("/webhooks", middleware.RequireAuth(r.userStore), webhooksHandler.GetWebhooks)
+	e.DELETE("/webhooks/:id", middleware.RequireAuth(r.userStore), webhooksHandler.DeleteWebhook)
+	e.GET("/webhooks/:id/deliveries", middleware.RequireAuth(r.userStore), webhooksHandler.GetDeliveries)
+	e.POST("/webhooks/:id/ping", middleware.RequireAuth(r.userStore), webhooksHandler.Ping)
+}
+
+func (r *Router) setupFiles(e *gin.Engine) {
+	if r.files == nil {
+		return
+	}
+	filesHandler := handlers.NewFilesHandler(r.files)
+	e.GET(constants.FilesPath+"/*key", filesHandler.GetFile)
+	e.PUT(constants.FilesPath+"/*key", filesHandler.PutFile)
+}
+
+func (r *Router) setupModeration(e *gin.Engine) {
+	moderationHandler := handlers.NewModerationHandler(r.animalsStore)
+	e.GET("/moderation", middleware.RequireAuth(r.userStore), middleware.RequireAdmin(), moderationHandler.GetQueue)
+	e.POST("/moderation/:id/resolve", middleware.RequireAuth(r.userStore), middleware.RequireAdmin(), moderationHandler.ResolveItem)
 }
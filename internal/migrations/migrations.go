@@ -0,0 +1,278 @@
+// Package migrations versions the database schema. Migrations are SQL files
+// embedded in the binary, named NNNN_description.up.sql with a matching
+// .down.sql, and applied in the order of their version.
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var (
+	ErrPendingMigrations = errors.New("database schema is not migrated")
+	ErrUnknownVersion    = errors.New("database schema is newer than this binary")
+	ErrNoMigrations      = errors.New("no migration to roll back")
+)
+
+// lockKey identifies the advisory lock migrations hold, so that replicas
+// starting together apply each migration once.
+const lockKey = 7_450_211
+
+// Migration is a versioned change to the schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration has been applied.
+type Status struct {
+	Migration
+	// AppliedAt is nil for pending migrations.
+	AppliedAt *time.Time
+}
+
+// SchemaMigration records an applied migration.
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New prepares the migrations embedded in the binary.
+func New(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db.Clauses(dbresolver.Write), migrations: migrations}, nil
+}
+
+// Load reads the migrations from the sql directory of fsys, ordered by
+// version. Every version needs both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		base := path.Base(name)
+		stem, direction, ok := strings.Cut(strings.TrimSuffix(base, ".sql"), ".")
+		version, description, found := strings.Cut(stem, "_")
+		number, err := strconv.Atoi(version)
+		if !ok || !found || err != nil || number <= 0 || direction != "up" && direction != "down" {
+			return nil, fmt.Errorf("invalid migration file name %q", base)
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		m := byVersion[number]
+		if m == nil {
+			m = &Migration{Version: number, Name: description}
+			byVersion[number] = m
+		}
+		if m.Name != description {
+			return nil, fmt.Errorf("migration %d is named both %q and %q", number, m.Name, description)
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d needs both an up and a down file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies the pending migrations, each in its own transaction, and
+// returns those it applied.
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range m.migrations {
+		done := false
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+			// Another replica may have applied it while this one waited.
+			var count int64
+			if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+			done = true
+			return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, err
+		}
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down rolls back the latest applied migrations, at most steps of them.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	var rolledBack []Migration
+	for len(rolledBack) < steps {
+		var migration Migration
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+			var latest SchemaMigration
+			err := tx.Order("version DESC").Take(&latest).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNoMigrations
+			}
+			if err != nil {
+				return err
+			}
+
+			var ok bool
+			if migration, ok = m.find(latest.Version); !ok {
+				return fmt.Errorf("%w: version %d", ErrUnknownVersion, latest.Version)
+			}
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+			return tx.Delete(&latest).Error
+		})
+		if errors.Is(err, ErrNoMigrations) && len(rolledBack) > 0 {
+			break
+		}
+		if err != nil {
+			return rolledBack, err
+		}
+		rolledBack = append(rolledBack, migration)
+	}
+	return rolledBack, nil
+}
+
+// Status lists every migration of the binary with when it was applied.
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Check fails unless every migration of the binary, and nothing newer, has
+// been applied.
+func (m *Migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+	for version := range applied {
+		if _, ok := m.find(version); !ok {
+			return fmt.Errorf("%w: version %d", ErrUnknownVersion, version)
+		}
+	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			return fmt.Errorf("%w: version %d %s is pending", ErrPendingMigrations, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied() (map[int]SchemaMigration, error) {
+	applied := map[int]SchemaMigration{}
+	if !m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+	var records []SchemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// createTable creates the version table under the lock, as concurrent
+// CREATE TABLE IF NOT EXISTS statements may still collide.
+func (m *Migrator) createTable() error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+		return tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamptz NOT NULL
+		)`).Error
+	})
+}
+
+func (m *Migrator) find(version int) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// lock holds the migration lock until the transaction ends.
+func lock(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error
+}
@@ -0,0 +1,49 @@
+package migrations_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Migrations of the binary", func(t *testing.T) {
+		loaded, err := migrations.Load(os.DirFS("."))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, loaded)
+		for i, m := range loaded {
+			assert.Equal(t, i+1, m.Version, "versions have no gaps")
+		}
+	})
+
+	t.Run("Ordered by version", func(t *testing.T) {
+		loaded, err := migrations.Load(fstest.MapFS{
+			"sql/0010_add_notes.up.sql":   {Data: []byte("ALTER TABLE animals ADD notes text;")},
+			"sql/0010_add_notes.down.sql": {Data: []byte("ALTER TABLE animals DROP notes;")},
+			"sql/0002_baseline.up.sql":    {Data: []byte("CREATE TABLE animals ();")},
+			"sql/0002_baseline.down.sql":  {Data: []byte("DROP TABLE animals;")},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, loaded, 2)
+		assert.Equal(t, migrations.Migration{Version: 2, Name: "baseline", Up: "CREATE TABLE animals ();", Down: "DROP TABLE animals;"}, loaded[0])
+		assert.Equal(t, "add_notes", loaded[1].Name)
+	})
+
+	t.Run("Invalid files", func(t *testing.T) {
+		for name, fsys := range map[string]fstest.MapFS{
+			"missing down": {"sql/0001_baseline.up.sql": {Data: []byte("SELECT 1;")}},
+			"no version":   {"sql/baseline.up.sql": {Data: []byte("SELECT 1;")}},
+			"direction":    {"sql/0001_baseline.sql": {Data: []byte("SELECT 1;")}},
+			"names differ": {
+				"sql/0001_baseline.up.sql":  {Data: []byte("SELECT 1;")},
+				"sql/0001_initial.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		} {
+			_, err := migrations.Load(fsys)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS
+	"moderation_items",
+	"email_suppressions",
+	"outbox_emails",
+	"webhook_deliveries",
+	"webhooks",
+	"reports",
+	"blocks",
+	"messages",
+	"conversations",
+	"notifications",
+	"saved_searches",
+	"swipe_events",
+	"favorites",
+	"seen_animals",
+	"photos",
+	"images",
+	"animals",
+	"user_settings",
+	"users";
@@ -0,0 +1,324 @@
+-- The schema as AutoMigrate left it. Every statement is conditional, so that
+-- databases created by AutoMigrate adopt it. Columns added to the original
+-- tables are added again, for databases that missed some of them.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS "users" (
+	"id" uuid DEFAULT uuid_generate_v4(),
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"email" text,
+	"password" text,
+	"locale" varchar(8) DEFAULT 'en',
+	PRIMARY KEY ("id"),
+	CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" varchar(8) DEFAULT 'en';
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_settings" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"user_id" uuid,
+	"type" text,
+	"min_age" bigint,
+	"max_age" bigint,
+	"gender" text[],
+	"location" text,
+	"vaccinated" boolean,
+	"sterilized" boolean,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_users_user_settings" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_settings_deleted_at" ON "user_settings" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "animals" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"name" text,
+	"age" decimal,
+	"type" text,
+	"description" text,
+	"gender" text,
+	"vaccinated" boolean,
+	"sterilized" boolean,
+	"latitude" decimal,
+	"longitude" decimal,
+	"status" varchar(16) DEFAULT 'AVAILABLE',
+	"fee" decimal,
+	"listing_changed_at" timestamptz,
+	"owner_id" uuid,
+	PRIMARY KEY ("id")
+);
+ALTER TABLE "animals"
+	ADD COLUMN IF NOT EXISTS "latitude" decimal,
+	ADD COLUMN IF NOT EXISTS "longitude" decimal,
+	ADD COLUMN IF NOT EXISTS "status" varchar(16) DEFAULT 'AVAILABLE',
+	ADD COLUMN IF NOT EXISTS "fee" decimal,
+	ADD COLUMN IF NOT EXISTS "listing_changed_at" timestamptz,
+	ADD COLUMN IF NOT EXISTS "owner_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_animals_owner_id" ON "animals" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_animals_status" ON "animals" ("status");
+CREATE INDEX IF NOT EXISTS "idx_animals_deleted_at" ON "animals" ("deleted_at");
+
+-- Full-text search over the name and description.
+ALTER TABLE "animals" ADD COLUMN IF NOT EXISTS "search_vector" tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'B')
+	) STORED;
+CREATE INDEX IF NOT EXISTS "idx_animals_search_vector" ON "animals" USING GIN ("search_vector");
+
+CREATE TABLE IF NOT EXISTS "images" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"animal_id" bigint,
+	"url" text,
+	"key" text,
+	"variant_thumb_url" text,
+	"variant_thumb_key" text,
+	"variant_card_url" text,
+	"variant_card_key" text,
+	"perceptual_hash" bigint,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_animals_image" FOREIGN KEY ("animal_id") REFERENCES "animals"("id")
+);
+ALTER TABLE "images"
+	ADD COLUMN IF NOT EXISTS "variant_thumb_url" text,
+	ADD COLUMN IF NOT EXISTS "variant_thumb_key" text,
+	ADD COLUMN IF NOT EXISTS "variant_card_url" text,
+	ADD COLUMN IF NOT EXISTS "variant_card_key" text,
+	ADD COLUMN IF NOT EXISTS "perceptual_hash" bigint;
+CREATE INDEX IF NOT EXISTS "idx_images_deleted_at" ON "images" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "photos" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"animal_id" bigint,
+	"image_url" text,
+	"key" text,
+	"variant_thumb_url" text,
+	"variant_thumb_key" text,
+	"variant_card_url" text,
+	"variant_card_key" text,
+	"position" bigint NOT NULL DEFAULT 0,
+	"perceptual_hash" bigint,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_animals_photos" FOREIGN KEY ("animal_id") REFERENCES "animals"("id")
+);
+ALTER TABLE "photos"
+	ADD COLUMN IF NOT EXISTS "variant_thumb_url" text,
+	ADD COLUMN IF NOT EXISTS "variant_thumb_key" text,
+	ADD COLUMN IF NOT EXISTS "variant_card_url" text,
+	ADD COLUMN IF NOT EXISTS "variant_card_key" text,
+	ADD COLUMN IF NOT EXISTS "position" bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS "perceptual_hash" bigint;
+CREATE INDEX IF NOT EXISTS "idx_photos_deleted_at" ON "photos" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "seen_animals" (
+	"user_id" uuid,
+	"animal_id" bigint,
+	"liked" boolean,
+	"seen_at" timestamptz,
+	PRIMARY KEY ("user_id", "animal_id"),
+	CONSTRAINT "fk_seen_animals_user" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+	CONSTRAINT "fk_seen_animals_animal" FOREIGN KEY ("animal_id") REFERENCES "animals"("id")
+);
+
+-- Likes used to be kept on seen_animals only, they become favorites when the
+-- table is created.
+DO $$
+BEGIN
+	IF to_regclass('favorites') IS NULL THEN
+		CREATE TABLE "favorites" (
+			"user_id" text,
+			"animal_id" bigint,
+			"note" text,
+			"created_at" timestamptz,
+			"updated_at" timestamptz,
+			PRIMARY KEY ("user_id", "animal_id"),
+			CONSTRAINT "fk_animals_favorite" FOREIGN KEY ("animal_id") REFERENCES "animals"("id")
+		);
+		INSERT INTO "favorites" ("user_id", "animal_id", "note", "created_at", "updated_at")
+			SELECT "user_id", "animal_id", '', "seen_at", "seen_at" FROM "seen_animals" WHERE "liked"
+			ON CONFLICT DO NOTHING;
+	END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS "swipe_events" (
+	"id" bigserial,
+	"user_id" text,
+	"animal_id" bigint,
+	"action" varchar(16),
+	"client" varchar(255),
+	"created_at" timestamptz,
+	"undone_event_id" bigint,
+	"previous_liked" boolean,
+	"previous_seen_at" timestamptz,
+	"added_favorite" boolean,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_swipe_events_user_id_id" ON "swipe_events" ("user_id", "id");
+
+CREATE TABLE IF NOT EXISTS "saved_searches" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"user_id" uuid,
+	"name" varchar(100),
+	"query" text,
+	"notify_in_app" boolean,
+	"notify_email" boolean,
+	"unsubscribe_token" varchar(64),
+	"last_run_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_saved_searches_unsubscribe_token" ON "saved_searches" ("unsubscribe_token");
+CREATE INDEX IF NOT EXISTS "idx_saved_searches_user_id" ON "saved_searches" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_saved_searches_deleted_at" ON "saved_searches" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+	"id" bigserial,
+	"user_id" uuid,
+	"type" varchar(32),
+	"title" text,
+	"body" text,
+	"link" text,
+	"animal_id" bigint,
+	"read_at" timestamptz,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id_id" ON "notifications" ("user_id", "id");
+
+CREATE TABLE IF NOT EXISTS "conversations" (
+	"id" bigserial,
+	"animal_id" bigint,
+	"adopter_id" uuid,
+	"owner_id" uuid,
+	"adopter_last_read_id" bigint,
+	"owner_last_read_id" bigint,
+	"last_message_at" timestamptz,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_conversations_last_message_at" ON "conversations" ("last_message_at");
+CREATE INDEX IF NOT EXISTS "idx_conversations_owner_id" ON "conversations" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_conversations_adopter_id" ON "conversations" ("adopter_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_conversations_animal_id_adopter_id" ON "conversations" ("animal_id", "adopter_id");
+
+CREATE TABLE IF NOT EXISTS "messages" (
+	"id" bigserial,
+	"conversation_id" bigint,
+	"sender_id" uuid,
+	"body" varchar(2000),
+	"attachment_url" text,
+	"attachment_key" text,
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_messages_sender_id_created_at" ON "messages" ("sender_id", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_messages_conversation_id_id" ON "messages" ("conversation_id", "id");
+
+CREATE TABLE IF NOT EXISTS "blocks" (
+	"blocker_id" uuid,
+	"blocked_id" uuid,
+	"created_at" timestamptz,
+	PRIMARY KEY ("blocker_id", "blocked_id")
+);
+
+CREATE TABLE IF NOT EXISTS "reports" (
+	"id" bigserial,
+	"reporter_id" uuid,
+	"reported_id" uuid,
+	"conversation_id" bigint,
+	"reason" varchar(400),
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_reports_reported_id" ON "reports" ("reported_id");
+CREATE INDEX IF NOT EXISTS "idx_reports_reporter_id" ON "reports" ("reporter_id");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+	"id" bigserial,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	"deleted_at" timestamptz,
+	"user_id" uuid,
+	"url" text,
+	"secret" varchar(64),
+	"events" text,
+	"active" boolean,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhooks_user_id" ON "webhooks" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_webhooks_deleted_at" ON "webhooks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+	"id" bigserial,
+	"webhook_id" bigint,
+	"event_type" varchar(32),
+	"payload" text,
+	"status" varchar(16),
+	"attempts" bigint,
+	"next_attempt_at" timestamptz,
+	"last_status_code" bigint,
+	"last_error" text,
+	"delivered_at" timestamptz,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("id"),
+	CONSTRAINT "fk_webhook_deliveries_webhook" FOREIGN KEY ("webhook_id") REFERENCES "webhooks"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_next_attempt_at" ON "webhook_deliveries" ("next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_webhook_id_id" ON "webhook_deliveries" ("webhook_id", "id");
+
+CREATE TABLE IF NOT EXISTS "outbox_emails" (
+	"id" bigserial,
+	"recipient" text,
+	"template" varchar(32),
+	"subject" text,
+	"text" text,
+	"html" text,
+	"status" varchar(16),
+	"attempts" bigint,
+	"next_attempt_at" timestamptz,
+	"last_error" text,
+	"sent_at" timestamptz,
+	"created_at" timestamptz,
+	"updated_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_emails_status_next_attempt_at" ON "outbox_emails" ("status", "next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_emails_recipient" ON "outbox_emails" ("recipient");
+
+CREATE TABLE IF NOT EXISTS "email_suppressions" (
+	"address" text,
+	"reason" text,
+	"created_at" timestamptz,
+	PRIMARY KEY ("address")
+);
+
+CREATE TABLE IF NOT EXISTS "moderation_items" (
+	"id" bigserial,
+	"animal_id" bigint,
+	"reason" text,
+	"duplicate_of_id" bigint,
+	"distance" bigint,
+	"status" text NOT NULL DEFAULT 'pending',
+	"created_at" timestamptz,
+	PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_moderation_items_status" ON "moderation_items" ("status");
+CREATE INDEX IF NOT EXISTS "idx_moderation_items_animal_id" ON "moderation_items" ("animal_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "moderation_items"
+	DROP COLUMN IF EXISTS "resolved_by",
+	DROP COLUMN IF EXISTS "resolved_at";
@@ -0,0 +1,3 @@
+ALTER TABLE "moderation_items"
+	ADD COLUMN IF NOT EXISTS "resolved_by" uuid,
+	ADD COLUMN IF NOT EXISTS "resolved_at" timestamptz;
@@ -0,0 +1,3 @@
+ALTER TABLE "favorites"
+	DROP CONSTRAINT IF EXISTS "fk_favorites_user",
+	ALTER COLUMN "user_id" TYPE text USING "user_id"::text;
@@ -0,0 +1,5 @@
+-- Favorites of users that no longer exist can not satisfy the foreign key.
+DELETE FROM "favorites" WHERE "user_id"::text NOT IN (SELECT "id"::text FROM "users");
+ALTER TABLE "favorites"
+	ALTER COLUMN "user_id" TYPE uuid USING "user_id"::uuid,
+	ADD CONSTRAINT "fk_favorites_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
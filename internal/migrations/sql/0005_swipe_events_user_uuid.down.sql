@@ -0,0 +1,3 @@
+ALTER TABLE "swipe_events"
+	DROP CONSTRAINT IF EXISTS "fk_swipe_events_user",
+	ALTER COLUMN "user_id" TYPE text USING "user_id"::text;
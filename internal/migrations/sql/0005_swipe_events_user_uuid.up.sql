@@ -0,0 +1,5 @@
+-- Swipes of users that no longer exist can not satisfy the foreign key.
+DELETE FROM "swipe_events" WHERE "user_id"::text NOT IN (SELECT "id"::text FROM "users");
+ALTER TABLE "swipe_events"
+	ALTER COLUMN "user_id" TYPE uuid USING "user_id"::uuid,
+	ADD CONSTRAINT "fk_swipe_events_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
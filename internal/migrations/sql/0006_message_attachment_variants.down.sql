@@ -0,0 +1,5 @@
+ALTER TABLE "messages"
+	DROP COLUMN IF EXISTS "attachment_variant_thumb_url",
+	DROP COLUMN IF EXISTS "attachment_variant_thumb_key",
+	DROP COLUMN IF EXISTS "attachment_variant_card_url",
+	DROP COLUMN IF EXISTS "attachment_variant_card_key";
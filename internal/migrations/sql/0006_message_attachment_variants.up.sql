@@ -0,0 +1,5 @@
+ALTER TABLE "messages"
+	ADD COLUMN IF NOT EXISTS "attachment_variant_thumb_url" text,
+	ADD COLUMN IF NOT EXISTS "attachment_variant_thumb_key" text,
+	ADD COLUMN IF NOT EXISTS "attachment_variant_card_url" text,
+	ADD COLUMN IF NOT EXISTS "attachment_variant_card_key" text;
@@ -1,38 +1,103 @@
 package services
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
-	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/awsS3"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/feed"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	webhook "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/webhooks"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrNotAnimalOwner   = errors.New("only the owner can change the animal")
+	ErrInvalidUploadKey = errors.New("key was not issued for the animal")
+	ErrUploadNotFound   = errors.New("uploaded photo not found")
+	ErrPhotoNotFound    = errors.New("photo not found")
 )
 
 type AnimalServiceI interface {
-	AddAnimal(animal *models.AnimalJSON) error
+	AddAnimal(ownerID uuid.UUID, animal *models.AnimalJSON) ([]models.DuplicateWarningJSON, error)
+	AddAnimalWithFiles(ownerID uuid.UUID, animal *models.AnimalJSON, files uploads.FileReaderI) ([]models.DuplicateWarningJSON, error)
+	PresignPhotoUploads(userID uuid.UUID, animalID string, files []models.PhotoUploadJSON) ([]models.PresignedUpload, error)
+	ConfirmPhotoUploads(userID uuid.UUID, animalID string, keys []string) ([]models.Photo, error)
+	AddPhotosWithFiles(userID uuid.UUID, animalID string, files uploads.FileReaderI) ([]models.Photo, error)
+	DeletePhoto(userID uuid.UUID, animalID, photoID string) error
+	ReorderPhotos(userID uuid.UUID, animalID string, positions []models.PhotoPositionJSON) (models.Animal, error)
+	SetCover(userID uuid.UUID, animalID string, photoID uint) (models.Animal, error)
 	GetAllAnimals(c *gin.Context) ([]models.Animal, error)
 	GetAnimalById(id string) (models.Animal, error)
 	GetAnimals(id uuid.UUID, c *gin.Context) ([]models.Animal, error)
 	GetLikedAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error)
-	MarkAsSeen(animalID string, userID uuid.UUID, like bool) error
+	MarkAsSeen(animalID string, userID uuid.UUID, like bool, client string) error
+	NotifyLiked(animalID uint)
+	UpdateAnimal(userID uuid.UUID, id string, update *models.AnimalUpdateJSON) (models.Animal, error)
 }
 
 type AnimalService struct {
-	s3Service   awsS3.S3ServiceI
-	animalStore animals.AnimalStoreI
+	photoService photos.PhotoServiceI
+	animalStore  animals.AnimalStoreI
+	userStore    users.UserStoreI
+	ranker       *ranking.Ranker
+	// notifications, events and webhooks may be nil, in which case no one is notified.
+	notifications NotificationProducerI
+	events        events.PublisherI
+	webhooks      WebhookProducerI
 }
 
-func NewAnimalService(animalStore animals.AnimalStoreI, s3Service awsS3.S3ServiceI) *AnimalService {
+func NewAnimalService(animalStore animals.AnimalStoreI, userStore users.UserStoreI, photoService photos.PhotoServiceI, ranker *ranking.Ranker, notifications NotificationProducerI, publisher events.PublisherI, webhooks WebhookProducerI) *AnimalService {
 	return &AnimalService{
-		animalStore: animalStore,
-		s3Service:   s3Service,
+		animalStore:   animalStore,
+		userStore:     userStore,
+		photoService:  photoService,
+		ranker:        ranker,
+		notifications: notifications,
+		events:        publisher,
+		webhooks:      webhooks,
 	}
 }
 
+// GetAnimals returns the swipe feed. Unless the client asked for a specific
+// order, the feed is personalized by the ranker.
 func (s *AnimalService) GetAnimals(id uuid.UUID, c *gin.Context) ([]models.Animal, error) {
-	return s.animalStore.GetNotSeenAnimals(id, c)
+	if s.ranker == nil || c.Query(constants.SortParam) != "" || c.Query(constants.SearchParam) != "" || pagination.IsCursorMode(c) {
+		return s.animalStore.GetNotSeenAnimals(id, c)
+	}
+
+	candidates, err := s.animalStore.GetFeedCandidates(id, c, constants.FeedCandidatePoolSize)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.userStore.GetUserSettings(id)
+	if err != nil {
+		return nil, err
+	}
+	signals, err := s.animalStore.GetSwipeSignals(id, constants.FeedSignalHistorySize)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := s.ranker.Rank(ranking.NewProfile(settings, signals), candidates, time.Now())
+	return pagination.PageSlice(c, ranked), nil
 }
 
 func (s *AnimalService) GetAllAnimals(c *gin.Context) ([]models.Animal, error) {
@@ -43,33 +108,573 @@ func (s *AnimalService) GetAnimalById(id string) (models.Animal, error) {
 	return s.animalStore.GetById(id)
 }
 
-func (s *AnimalService) AddAnimal(animal *models.AnimalJSON) error {
+// AddAnimal stores the image and photos concurrently, then the animal. If
+// any of it fails, the stored files are deleted again. It warns about other
+// listings that show the same pictures.
+func (s *AnimalService) AddAnimal(ownerID uuid.UUID, animal *models.AnimalJSON) ([]models.DuplicateWarningJSON, error) {
 	a := models.FromAnimalJSON(animal)
-	result, err := s.s3Service.UploadSinglePhoto(animal.Image, animal.Name+"_image")
+	a.OwnerID = &ownerID
+
+	batch := newPhotoBatch(s.photoService, constants.PhotoUploadWorkers)
+	batch.Go(func() (models.Photo, error) {
+		return s.photoService.UploadSinglePhoto(animal.Image, animal.Name+"_image")
+	})
+	for _, uri := range animal.Photos {
+		uri := uri
+		if !batch.Go(func() (models.Photo, error) { return s.photoService.UploadSinglePhoto(uri, animal.Name) }) {
+			break
+		}
+	}
+	return s.createWithPhotos(a, batch, 0)
+}
+
+// AddAnimalWithFiles adds the animal with its image and photos streamed from
+// a multipart upload instead of base64 data URIs. Each file is read whole
+// before it is handed to an upload worker, so that the next one can be read
+// meanwhile.
+func (s *AnimalService) AddAnimalWithFiles(ownerID uuid.UUID, animal *models.AnimalJSON, files uploads.FileReaderI) ([]models.DuplicateWarningJSON, error) {
+	a := models.FromAnimalJSON(animal)
+	a.OwnerID = &ownerID
+
+	batch := newPhotoBatch(s.photoService, constants.PhotoUploadWorkers)
+	image, count := -1, 0
+	for {
+		file, err := files.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			batch.Rollback()
+			return nil, err
+		}
+
+		prefix := animal.Name
+		switch {
+		case file.Field == constants.ImageFormField && image < 0:
+			prefix += "_image"
+			image = count
+		case file.Field == constants.PhotosFormField:
+		default:
+			batch.Rollback()
+			return nil, fmt.Errorf("%w: %q", uploads.ErrUnexpectedPart, file.Field)
+		}
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			batch.Rollback()
+			return nil, err
+		}
+		if !batch.Go(func() (models.Photo, error) {
+			return s.photoService.UploadPhotoFromReader(bytes.NewReader(data), prefix)
+		}) {
+			break
+		}
+		count++
+	}
+
+	if image < 0 {
+		batch.Rollback()
+		return nil, fmt.Errorf("%w: %s", uploads.ErrMissingPart, constants.ImageFormField)
+	}
+	return s.createWithPhotos(a, batch, image)
+}
+
+// createWithPhotos waits for the photos of the batch and stores the animal
+// with them, the one at image being its image. The stored files are deleted
+// if either fails.
+func (s *AnimalService) createWithPhotos(a *models.Animal, batch *photoBatch, image int) ([]models.DuplicateWarningJSON, error) {
+	stored, err := batch.Wait()
+	if err == nil {
+		for i, photo := range stored {
+			if i == image {
+				a.Image = toImage(photo)
+			} else {
+				a.Photos = append(a.Photos, photo)
+			}
+		}
+		err = s.create(a)
+	}
 	if err != nil {
-		return err
+		batch.Rollback()
+		return nil, err
 	}
-	a.Image = models.Image{URL: result.Location, Key: *result.Key}
+	return flagDuplicates(s.animalStore, *a), nil
+}
 
-	photosURLs, err := s.s3Service.UploadPhotos(animal.Photos, animal.Name)
+// flagDuplicates queues the animal for moderation when another listing shows
+// a near-identical image, as happens when a shelter posts an animal twice or
+// photos are reused by scammers. The animal is kept either way, so failures
+// are only logged.
+func flagDuplicates(store animals.AnimalStoreI, a models.Animal) []models.DuplicateWarningJSON {
+	var hashes []int64
+	if a.Image.PerceptualHash != nil {
+		hashes = append(hashes, *a.Image.PerceptualHash)
+	}
+	for _, photo := range a.Photos {
+		if photo.PerceptualHash != nil {
+			hashes = append(hashes, *photo.PerceptualHash)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	similar, err := store.FindSimilarAnimals(hashes, a.ID, constants.DuplicateHashDistance)
+	if err != nil {
+		log.Error().Err(err).Uint("animalID", a.ID).Msg("cant look for duplicate listings")
+		return nil
+	}
+	if len(similar) == 0 {
+		return nil
+	}
+
+	warnings := make([]models.DuplicateWarningJSON, 0, len(similar))
+	items := make([]models.ModerationItem, 0, len(similar))
+	for _, other := range similar {
+		duplicateOf := other.AnimalID
+		items = append(items, models.ModerationItem{
+			AnimalID:      a.ID,
+			Reason:        models.ModerationDuplicatePhoto,
+			DuplicateOfID: &duplicateOf,
+			Distance:      other.Distance,
+			Status:        models.ModerationPending,
+		})
+		warnings = append(warnings, models.DuplicateWarningJSON{AnimalID: other.AnimalID, Distance: other.Distance})
+	}
+	if err := store.AddModerationItems(items); err != nil {
+		log.Error().Err(err).Uint("animalID", a.ID).Msg("cant queue duplicate listing for moderation")
+	}
+	return warnings
+}
+
+// PresignPhotoUploads issues URLs the owner uploads photos of the animal to
+// directly. The photos are added to the animal once they are confirmed.
+func (s *AnimalService) PresignPhotoUploads(userID uuid.UUID, animalID string, files []models.PhotoUploadJSON) ([]models.PresignedUpload, error) {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(animal.Photos)+len(files) > constants.MaxPhotos {
+		return nil, uploads.ErrTooManyFiles
+	}
+
+	result := make([]models.PresignedUpload, 0, len(files))
+	for _, file := range files {
+		if file.Size > constants.MaxPhotoSize {
+			return nil, uploads.ErrFileTooLarge
+		}
+		ext, err := uploads.ImageExtension(file.ContentType)
+		if err != nil {
+			return nil, err
+		}
+
+		key := photoUploadPrefix(animal.ID) + uuid.NewString() + ext
+		upload, err := s.photoService.PresignPhotoUpload(key, file.ContentType, file.Size, constants.PresignedUploadExpiry)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, upload)
+	}
+	return result, nil
+}
+
+// ConfirmPhotoUploads adds directly uploaded photos to the animal, after
+// checking that they were issued for it, exist and are within limits. The
+// photos are stored in every size and the originals are deleted.
+func (s *AnimalService) ConfirmPhotoUploads(userID uuid.UUID, animalID string, keys []string) ([]models.Photo, error) {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(animal.Photos)+len(keys) > constants.MaxPhotos {
+		return nil, uploads.ErrTooManyFiles
+	}
+
+	added := make(map[string]bool, len(animal.Photos)+len(keys))
+	for _, photo := range animal.Photos {
+		added[photo.Key] = true
+	}
+
+	batch := newPhotoBatch(s.photoService, constants.PhotoUploadWorkers)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, photoUploadPrefix(animal.ID)) || added[key] {
+			batch.Rollback()
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUploadKey, key)
+		}
+		added[key] = true
+
+		info, err := s.photoService.GetPhotoInfo(key)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = fmt.Errorf("%w: %s", ErrUploadNotFound, key)
+		} else if err == nil && info.Size > constants.MaxPhotoSize {
+			err = uploads.ErrFileTooLarge
+		}
+		if err != nil {
+			batch.Rollback()
+			return nil, err
+		}
+		key := key
+		if !batch.Go(func() (models.Photo, error) { return s.photoService.ProcessUploadedPhoto(key, info.ContentType) }) {
+			break
+		}
+	}
+
+	photos, err := s.addPhotos(animal, batch)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if err := s.photoService.DeletePhoto(key); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("cant delete uploaded original")
+		}
+	}
+	return photos, nil
+}
+
+// AddPhotosWithFiles adds photos streamed from a multipart upload to the
+// animal, after its current photos.
+func (s *AnimalService) AddPhotosWithFiles(userID uuid.UUID, animalID string, files uploads.FileReaderI) ([]models.Photo, error) {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := newPhotoBatch(s.photoService, constants.PhotoUploadWorkers)
+	prefix := fmt.Sprintf("animals/%d/photo", animal.ID)
+	count := 0
+	for {
+		file, err := files.Next()
+		if err == io.EOF {
+			break
+		}
+		if err == nil && file.Field != constants.PhotosFormField {
+			err = fmt.Errorf("%w: %q", uploads.ErrUnexpectedPart, file.Field)
+		} else if err == nil && len(animal.Photos)+count >= constants.MaxPhotos {
+			err = uploads.ErrTooManyFiles
+		}
+		var data []byte
+		if err == nil {
+			data, err = io.ReadAll(file)
+		}
+		if err != nil {
+			batch.Rollback()
+			return nil, err
+		}
+
+		if !batch.Go(func() (models.Photo, error) {
+			return s.photoService.UploadPhotoFromReader(bytes.NewReader(data), prefix)
+		}) {
+			break
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("%w: %s", uploads.ErrMissingPart, constants.PhotosFormField)
+	}
+	return s.addPhotos(animal, batch)
+}
+
+// addPhotos waits for the photos of the batch and adds them to the animal,
+// after its current photos. The stored files are deleted if either fails.
+func (s *AnimalService) addPhotos(animal models.Animal, batch *photoBatch) ([]models.Photo, error) {
+	photos, err := batch.Wait()
+	if err == nil {
+		position := nextPhotoPosition(animal.Photos)
+		for i := range photos {
+			photos[i].AnimalID = animal.ID
+			photos[i].Position = position + i
+		}
+		err = s.animalStore.AddPhotos(photos)
+	}
+	if err != nil {
+		batch.Rollback()
+		return nil, err
+	}
+
+	after := animal
+	after.Photos = append(slices.Clone(animal.Photos), photos...)
+	s.markListingChange(animal, after)
+	return photos, nil
+}
+
+// markListingChange bumps ListingChangedAt after a material change to the
+// media of the listing. The change is already saved, so failing to bump it
+// is only logged.
+func (s *AnimalService) markListingChange(before, after models.Animal) models.Animal {
+	if !feed.IsMaterialChange(before, after) {
+		return after
+	}
+	now := time.Now()
+	after.ListingChangedAt = &now
+	if err := s.animalStore.UpdateAnimal(&after); err != nil {
+		log.Error().Err(err).Uint("animalID", after.ID).Msg("cant mark listing as changed")
+	}
+	return after
+}
+
+// DeletePhoto removes the photo from the animal and deletes its files,
+// unless the cover image still shows them.
+func (s *AnimalService) DeletePhoto(userID uuid.UUID, animalID, photoID string) error {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(photoID, 10, 64)
 	if err != nil {
 		return err
 	}
-	a.Photos = photosURLs
+	photo, err := findPhoto(animal, uint(id))
+	if err != nil {
+		return err
+	}
+
+	if err := s.animalStore.DeletePhoto(photo); err != nil {
+		return err
+	}
+	s.deleteUnusedFiles(photo.Keys(), animal.Image.Keys())
+	return nil
+}
+
+// ReorderPhotos moves the photos to the given positions. Photos that are
+// not listed keep theirs; photos sharing a position are ordered by age.
+func (s *AnimalService) ReorderPhotos(userID uuid.UUID, animalID string, positions []models.PhotoPositionJSON) (models.Animal, error) {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return models.Animal{}, err
+	}
+
+	changed := make([]models.Photo, 0, len(positions))
+	for _, p := range positions {
+		i := slices.IndexFunc(animal.Photos, func(photo models.Photo) bool { return photo.ID == p.ID })
+		if i < 0 {
+			return models.Animal{}, fmt.Errorf("%w: %d", ErrPhotoNotFound, p.ID)
+		}
+		animal.Photos[i].Position = p.Position
+		changed = append(changed, animal.Photos[i])
+	}
+
+	if err := s.animalStore.UpdatePhotoPositions(changed); err != nil {
+		return models.Animal{}, err
+	}
+	sort.SliceStable(animal.Photos, func(i, j int) bool {
+		a, b := animal.Photos[i], animal.Photos[j]
+		return a.Position < b.Position || a.Position == b.Position && a.ID < b.ID
+	})
+	return animal, nil
+}
+
+// SetCover makes the photo the animal's image. The files of the previous
+// image are deleted unless one of the photos shows them.
+func (s *AnimalService) SetCover(userID uuid.UUID, animalID string, photoID uint) (models.Animal, error) {
+	animal, err := s.ownAnimal(userID, animalID)
+	if err != nil {
+		return models.Animal{}, err
+	}
+	photo, err := findPhoto(animal, photoID)
+	if err != nil {
+		return models.Animal{}, err
+	}
+
+	previous := animal.Image
+	image := animal.Image
+	image.AnimalID = animal.ID
+	image.URL, image.Key, image.Variants, image.PerceptualHash = photo.ImageURL, photo.Key, photo.Variants, photo.PerceptualHash
+	if err := s.animalStore.SaveImage(&image); err != nil {
+		return models.Animal{}, err
+	}
+	before := animal
+	animal.Image = image
+	animal = s.markListingChange(before, animal)
+
+	var used []string
+	for _, p := range animal.Photos {
+		used = append(used, p.Keys()...)
+	}
+	s.deleteUnusedFiles(previous.Keys(), used)
+	return animal, nil
+}
+
+func findPhoto(animal models.Animal, photoID uint) (models.Photo, error) {
+	for _, photo := range animal.Photos {
+		if photo.ID == photoID {
+			return photo, nil
+		}
+	}
+	return models.Photo{}, fmt.Errorf("%w: %d", ErrPhotoNotFound, photoID)
+}
+
+// deleteUnusedFiles deletes the stored files that are not among the used
+// ones. Failing to delete them does not fail the change.
+func (s *AnimalService) deleteUnusedFiles(keys, used []string) {
+	for _, key := range keys {
+		if slices.Contains(used, key) {
+			continue
+		}
+		if err := s.photoService.DeletePhoto(key); err != nil {
+			log.Error().Err(err).Str("key", key).Msg("cant delete photo file")
+		}
+	}
+}
+
+func nextPhotoPosition(photos []models.Photo) int {
+	next := 0
+	for _, photo := range photos {
+		next = max(next, photo.Position+1)
+	}
+	return next
+}
+
+// ownAnimal loads the animal, failing unless the user owns it.
+func (s *AnimalService) ownAnimal(userID uuid.UUID, animalID string) (models.Animal, error) {
+	animal, err := s.animalStore.GetById(animalID)
+	if err != nil {
+		return models.Animal{}, err
+	}
+	if animal.OwnerID == nil || *animal.OwnerID != userID {
+		return models.Animal{}, ErrNotAnimalOwner
+	}
+	return animal, nil
+}
+
+func toImage(photo models.Photo) models.Image {
+	return models.Image{URL: photo.ImageURL, Key: photo.Key, Variants: photo.Variants, PerceptualHash: photo.PerceptualHash}
+}
 
+func photoUploadPrefix(animalID uint) string {
+	return fmt.Sprintf("animals/%d/uploads/", animalID)
+}
+
+// create stores the new animal and announces it.
+func (s *AnimalService) create(a *models.Animal) error {
+	for i := range a.Photos {
+		a.Photos[i].Position = i
+	}
 	if err := s.animalStore.AddAnimal(a); err != nil {
 		return err
 	}
+
+	if s.events != nil {
+		if err := s.events.Publish(events.TopicAnimalCreated, nil, models.ToAnimalJSON(*a)); err != nil {
+			log.Error().Err(err).Uint("animalID", a.ID).Msg("cant publish new animal")
+		}
+	}
+	s.enqueueWebhook(*a, webhook.EventAnimalCreated, models.ToAnimalJSON(*a))
 	return nil
 }
 
-func (s *AnimalService) MarkAsSeen(animalID string, userID uuid.UUID, like bool) error {
+// UpdateAnimal applies the update to the listing of the user. Material
+// changes bump ListingChangedAt so that users who passed on the animal may see
+// it again.
+func (s *AnimalService) UpdateAnimal(userID uuid.UUID, id string, update *models.AnimalUpdateJSON) (models.Animal, error) {
+	animal, err := s.ownAnimal(userID, id)
+	if err != nil {
+		return models.Animal{}, err
+	}
+
+	before := animal
+	update.Apply(&animal)
+	if feed.IsMaterialChange(before, animal) {
+		now := time.Now()
+		animal.ListingChangedAt = &now
+	}
+
+	if err := s.animalStore.UpdateAnimal(&animal); err != nil {
+		return models.Animal{}, err
+	}
+
+	if before.Status != animal.Status {
+		s.notifyStatusChange(animal)
+		s.enqueueWebhook(animal, webhook.EventStatusChanged, gin.H{
+			"animalId":       animal.ID,
+			"status":         animal.Status,
+			"previousStatus": before.Status,
+		})
+	}
+	return animal, nil
+}
+
+// notifyStatusChange tells the users who liked the animal about its new
+// status. Failing to notify them does not fail the update.
+func (s *AnimalService) notifyStatusChange(animal models.Animal) {
+	if s.notifications == nil && s.events == nil {
+		return
+	}
+
+	userIDs, err := s.animalStore.GetFavoritedBy(animal.ID)
+	if err != nil {
+		log.Error().Err(err).Uint("animalID", animal.ID).Msg("cant get users to notify of status change")
+		return
+	}
+
+	var title string
+	switch animal.Status {
+	case models.AnimalStatusAdopted:
+		title = animal.Name + " was adopted"
+	case models.AnimalStatusReserved:
+		title = animal.Name + " is reserved"
+	default:
+		title = animal.Name + " is available again"
+	}
+
+	if s.events != nil && len(userIDs) > 0 {
+		data := gin.H{"animalId": animal.ID, "status": animal.Status}
+		if err := s.events.Publish(events.TopicAnimalStatus, userIDs, data); err != nil {
+			log.Error().Err(err).Uint("animalID", animal.ID).Msg("cant publish status change")
+		}
+	}
+
+	if s.notifications != nil {
+		animalID := animal.ID
+		err = s.notifications.NotifyUsers(userIDs, models.Notification{
+			Type:     models.NotificationTypeAnimalStatus,
+			Title:    title,
+			AnimalID: &animalID,
+		})
+		if err != nil {
+			log.Error().Err(err).Uint("animalID", animal.ID).Msg("cant notify users of status change")
+		}
+	}
+}
+
+func (s *AnimalService) MarkAsSeen(animalID string, userID uuid.UUID, like bool, client string) error {
 	aID, err := strconv.ParseUint(animalID, 10, 64)
 	if err != nil {
 		return err
 	}
 
-	return s.animalStore.MarkAsSeen(uint(aID), userID, like)
+	addedFavorite, err := s.animalStore.MarkAsSeen(uint(aID), userID, like, client)
+	if err != nil {
+		return err
+	}
+	if addedFavorite {
+		s.NotifyLiked(uint(aID))
+	}
+	return nil
+}
+
+// NotifyLiked tells the animal's owner someone added it to their favorites.
+func (s *AnimalService) NotifyLiked(animalID uint) {
+	if s.webhooks == nil {
+		return
+	}
+	animal, err := s.animalStore.GetById(strconv.FormatUint(uint64(animalID), 10))
+	if err != nil {
+		log.Error().Err(err).Uint("animalID", animalID).Msg("cant load liked animal for webhooks")
+		return
+	}
+	s.enqueueWebhook(animal, webhook.EventAnimalLiked, gin.H{"animalId": animal.ID})
+}
+
+// enqueueWebhook sends the event to the webhooks of the animal's owner.
+// Failing to queue it does not fail the change.
+func (s *AnimalService) enqueueWebhook(animal models.Animal, eventType string, data interface{}) {
+	if s.webhooks == nil || animal.OwnerID == nil {
+		return
+	}
+	if err := s.webhooks.Enqueue(*animal.OwnerID, eventType, data); err != nil {
+		log.Error().Err(err).Uint("animalID", animal.ID).Str("event", eventType).Msg("cant queue webhook")
+	}
 }
 
 func (s *AnimalService) GetLikedAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error) {
@@ -1,26 +1,39 @@
 package services_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/webhooks"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestAnimalService_AddAnimal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	animalJSON := &models.AnimalJSON{
 		Name:   "Test Animal",
@@ -29,19 +42,22 @@ func TestAnimalService_AddAnimal(t *testing.T) {
 	}
 
 	animal := models.FromAnimalJSON(animalJSON)
-	uploadOutput := &manager.UploadOutput{
-		Location: "https://s3.amazonaws.com/findyourpet-kach/Test_Animal_image.jpg",
-		Key:      aws.String("Test_Animal_image.jpg"),
+	ownerID := uuid.New()
+	imageOutput := models.Photo{
+		ImageURL: "https://s3.amazonaws.com/findyourpet-kach/Test_Animal_image_full.jpg",
+		Key:      "Test_Animal_image_full.jpg",
+		Variants: models.ImageVariants{ThumbURL: "https://s3.amazonaws.com/findyourpet-kach/Test_Animal_image_thumb.jpg", ThumbKey: "Test_Animal_image_thumb.jpg"},
 	}
-	photoOutput := models.Photo{ImageURL: uploadOutput.Location, Key: *uploadOutput.Key}
+	photoOutput := models.Photo{ImageURL: "https://s3.amazonaws.com/findyourpet-kach/Test_Animal_full.jpg", Key: "Test_Animal_full.jpg"}
 
-	mockS3Service.EXPECT().UploadSinglePhoto(animalJSON.Image, animalJSON.Name+"_image").Return(uploadOutput, nil)
-	mockS3Service.EXPECT().UploadPhotos(animalJSON.Photos, animalJSON.Name).Return([]models.Photo{photoOutput}, nil)
+	mockPhotoService.EXPECT().UploadSinglePhoto(animalJSON.Image, animalJSON.Name+"_image").Return(imageOutput, nil)
+	mockPhotoService.EXPECT().UploadSinglePhoto(animalJSON.Photos[0], animalJSON.Name).Return(photoOutput, nil)
 
 	expectedAnimal := animal
 	expectedAnimal.Image = models.Image{
-		URL: uploadOutput.Location,
-		Key: *uploadOutput.Key,
+		URL:      imageOutput.ImageURL,
+		Key:      imageOutput.Key,
+		Variants: imageOutput.Variants,
 	}
 	expectedAnimal.Photos = []models.Photo{photoOutput}
 
@@ -49,20 +65,477 @@ func TestAnimalService_AddAnimal(t *testing.T) {
 		assert.Equal(t, expectedAnimal.Name, arg.Name)
 		assert.Equal(t, expectedAnimal.Image, arg.Image)
 		assert.Equal(t, expectedAnimal.Photos, arg.Photos)
+		assert.Equal(t, ownerID, *arg.OwnerID)
 		return nil
 	})
 
-	err := service.AddAnimal(animalJSON)
+	_, err := service.AddAnimal(ownerID, animalJSON)
 	assert.NoError(t, err)
 }
 
+func TestAnimalService_AddAnimalUploadWorkers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	animalJSON := &models.AnimalJSON{Name: "Luna", Image: "image"}
+	for i := 0; i < constants.MaxPhotos; i++ {
+		animalJSON.Photos = append(animalJSON.Photos, fmt.Sprint(i))
+	}
+
+	var running, most int32
+	mockPhotoService.EXPECT().UploadSinglePhoto(gomock.Any(), gomock.Any()).DoAndReturn(func(uri, prefix string) (models.Photo, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for m := atomic.LoadInt32(&most); n > m && !atomic.CompareAndSwapInt32(&most, m, n); m = atomic.LoadInt32(&most) {
+		}
+		time.Sleep(10 * time.Millisecond)
+		return models.Photo{Key: prefix + "_" + uri}, nil
+	}).Times(constants.MaxPhotos + 1)
+	mockAnimalStore.EXPECT().AddAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+		assert.Equal(t, "Luna_image_image", arg.Image.Key)
+		for i, photo := range arg.Photos {
+			assert.Equal(t, fmt.Sprintf("Luna_%d", i), photo.Key)
+		}
+		return nil
+	})
+
+	_, err := service.AddAnimal(uuid.New(), animalJSON)
+	assert.NoError(t, err)
+	assert.Greater(t, most, int32(1))
+	assert.LessOrEqual(t, most, int32(constants.PhotoUploadWorkers))
+}
+
+func TestAnimalService_AddAnimalFlagsDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	animalJSON := &models.AnimalJSON{Name: "Luna", Image: "image", Photos: []string{"photo", "png"}}
+	imageHash, photoHash := int64(0x0f0f), int64(-42)
+
+	mockPhotoService.EXPECT().UploadSinglePhoto("image", "Luna_image").Return(models.Photo{Key: "image.jpg", PerceptualHash: &imageHash}, nil)
+	mockPhotoService.EXPECT().UploadSinglePhoto("photo", "Luna").Return(models.Photo{Key: "photo.jpg", PerceptualHash: &photoHash}, nil)
+	mockPhotoService.EXPECT().UploadSinglePhoto("png", "Luna").Return(models.Photo{Key: "photo.png"}, nil)
+	mockAnimalStore.EXPECT().AddAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+		assert.Equal(t, &imageHash, arg.Image.PerceptualHash)
+		arg.ID = 9
+		return nil
+	})
+	mockAnimalStore.EXPECT().FindSimilarAnimals([]int64{imageHash, photoHash}, uint(9), constants.DuplicateHashDistance).
+		Return([]models.SimilarAnimal{{AnimalID: 4, Distance: 0}, {AnimalID: 5, Distance: 3}}, nil)
+	mockAnimalStore.EXPECT().AddModerationItems(gomock.Any()).DoAndReturn(func(items []models.ModerationItem) error {
+		assert.Len(t, items, 2)
+		assert.Equal(t, uint(9), items[0].AnimalID)
+		assert.Equal(t, uint(4), *items[0].DuplicateOfID)
+		assert.Equal(t, models.ModerationDuplicatePhoto, items[0].Reason)
+		assert.Equal(t, models.ModerationPending, items[0].Status)
+		assert.Equal(t, 3, items[1].Distance)
+		return nil
+	})
+
+	warnings, err := service.AddAnimal(uuid.New(), animalJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DuplicateWarningJSON{{AnimalID: 4, Distance: 0}, {AnimalID: 5, Distance: 3}}, warnings)
+}
+
+func TestAnimalService_AddAnimalRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	animalJSON := &models.AnimalJSON{Name: "Luna", Image: "image", Photos: []string{"a", "b", "c"}}
+	ownerID := uuid.New()
+	image := models.Photo{Key: "Luna_image_full.jpg", Variants: models.ImageVariants{ThumbKey: "Luna_image_thumb.jpg"}}
+
+	t.Run("Stored photos are deleted when one fails", func(t *testing.T) {
+		mockPhotoService.EXPECT().UploadSinglePhoto("image", "Luna_image").Return(image, nil)
+		mockPhotoService.EXPECT().UploadSinglePhoto(gomock.Any(), "Luna").DoAndReturn(func(uri, prefix string) (models.Photo, error) {
+			if uri == "b" {
+				return models.Photo{}, uploads.ErrMalformedImage
+			}
+			return models.Photo{Key: uri + ".jpg"}, nil
+		}).MaxTimes(3)
+		// Uploads started after the failure are skipped, so only what was
+		// stored is deleted.
+		mockPhotoService.EXPECT().DeletePhoto("Luna_image_full.jpg").Return(nil)
+		mockPhotoService.EXPECT().DeletePhoto("Luna_image_thumb.jpg").Return(nil)
+		mockPhotoService.EXPECT().DeletePhoto("a.jpg").Return(nil).MaxTimes(1)
+		mockPhotoService.EXPECT().DeletePhoto("c.jpg").Return(nil).MaxTimes(1)
+
+		_, err := service.AddAnimal(ownerID, animalJSON)
+		assert.ErrorIs(t, err, uploads.ErrMalformedImage)
+	})
+
+	t.Run("Stored photos are deleted when the animal is not saved", func(t *testing.T) {
+		mockPhotoService.EXPECT().UploadSinglePhoto("image", "Luna_image").Return(image, nil)
+		mockPhotoService.EXPECT().UploadSinglePhoto(gomock.Any(), "Luna").DoAndReturn(func(uri, prefix string) (models.Photo, error) {
+			return models.Photo{Key: uri + ".jpg"}, nil
+		}).Times(3)
+		mockAnimalStore.EXPECT().AddAnimal(gomock.Any()).Return(fmt.Errorf("db is down"))
+		for _, key := range []string{"Luna_image_full.jpg", "Luna_image_thumb.jpg", "a.jpg", "b.jpg", "c.jpg"} {
+			mockPhotoService.EXPECT().DeletePhoto(key).Return(nil)
+		}
+
+		_, err := service.AddAnimal(ownerID, animalJSON)
+		assert.Error(t, err)
+	})
+}
+
+func TestAnimalService_AddAnimalWithFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	animalJSON := &models.AnimalJSON{Name: "Luna"}
+	ownerID := uuid.New()
+
+	upload := func(content, key string) func(io.Reader, string) (models.Photo, error) {
+		return func(file io.Reader, prefix string) (models.Photo, error) {
+			data, err := io.ReadAll(file)
+			assert.Equal(t, content, string(data))
+			return models.Photo{ImageURL: "https://s3.amazonaws.com/findyourpet-kach/" + key, Key: key}, err
+		}
+	}
+
+	t.Run("Image and photos are streamed", func(t *testing.T) {
+		files := multipartFiles(t, 10, [2]string{"image", "image"}, [2]string{"photos", "photo"})
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna_image").DoAndReturn(upload("image", "Luna_image.jpg"))
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna").DoAndReturn(upload("photo", "Luna.jpg"))
+		mockAnimalStore.EXPECT().AddAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+			assert.Equal(t, "Luna_image.jpg", arg.Image.Key)
+			assert.Len(t, arg.Photos, 1)
+			assert.Equal(t, "Luna.jpg", arg.Photos[0].Key)
+			assert.Equal(t, ownerID, *arg.OwnerID)
+			return nil
+		})
+
+		_, err := service.AddAnimalWithFiles(ownerID, animalJSON, files)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Missing image", func(t *testing.T) {
+		files := multipartFiles(t, 10, [2]string{"photos", "photo"})
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna").DoAndReturn(upload("photo", "Luna.jpg"))
+		mockPhotoService.EXPECT().DeletePhoto("Luna.jpg").Return(nil)
+
+		_, err := service.AddAnimalWithFiles(ownerID, animalJSON, files)
+		assert.ErrorIs(t, err, uploads.ErrMissingPart)
+	})
+
+	t.Run("File too large", func(t *testing.T) {
+		files := multipartFiles(t, 3, [2]string{"photos", "ok"}, [2]string{"image", "image"})
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna").DoAndReturn(upload("ok", "Luna.jpg"))
+		mockPhotoService.EXPECT().DeletePhoto("Luna.jpg").Return(nil)
+
+		_, err := service.AddAnimalWithFiles(ownerID, animalJSON, files)
+		assert.ErrorIs(t, err, uploads.ErrFileTooLarge)
+	})
+}
+
+func TestAnimalService_PresignPhotoUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	ownerID := uuid.New()
+	animal := models.Animal{Name: "Luna", OwnerID: &ownerID}
+	animal.ID = 3
+
+	t.Run("Keys are issued under the animal", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockPhotoService.EXPECT().PresignPhotoUpload(gomock.Any(), "image/png", int64(1024), constants.PresignedUploadExpiry).
+			DoAndReturn(func(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error) {
+				assert.True(t, strings.HasPrefix(key, "animals/3/uploads/"))
+				assert.True(t, strings.HasSuffix(key, ".png"))
+				return models.PresignedUpload{Key: key, Method: http.MethodPut}, nil
+			})
+
+		presigned, err := service.PresignPhotoUploads(ownerID, "3", []models.PhotoUploadJSON{{ContentType: "image/png", Size: 1024}})
+		assert.NoError(t, err)
+		assert.Len(t, presigned, 1)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+
+		_, err := service.PresignPhotoUploads(uuid.New(), "3", []models.PhotoUploadJSON{{ContentType: "image/png", Size: 1024}})
+		assert.ErrorIs(t, err, services.ErrNotAnimalOwner)
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil).Times(2)
+
+		_, err := service.PresignPhotoUploads(ownerID, "3", []models.PhotoUploadJSON{{ContentType: "image/png", Size: constants.MaxPhotoSize + 1}})
+		assert.ErrorIs(t, err, uploads.ErrFileTooLarge)
+		_, err = service.PresignPhotoUploads(ownerID, "3", []models.PhotoUploadJSON{{ContentType: "application/pdf", Size: 1024}})
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+	})
+}
+
+func TestAnimalService_ConfirmPhotoUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	ownerID := uuid.New()
+	animal := models.Animal{Name: "Luna", OwnerID: &ownerID, Photos: []models.Photo{{Key: "animals/3/uploads/old.jpg"}}}
+	animal.ID = 3
+	key := "animals/3/uploads/new.jpg"
+
+	t.Run("Uploaded photos are added", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockPhotoService.EXPECT().GetPhotoInfo(key).Return(storage.Object{Key: key, URL: "https://s3/" + key, Size: 1024, ContentType: "image/jpeg"}, nil)
+		photo := models.Photo{
+			ImageURL: "https://s3/animals/3/uploads/new_full.jpg",
+			Key:      "animals/3/uploads/new_full.jpg",
+			Variants: models.ImageVariants{ThumbKey: "animals/3/uploads/new_thumb.jpg", CardKey: "animals/3/uploads/new_card.jpg"},
+		}
+		mockPhotoService.EXPECT().ProcessUploadedPhoto(key, "image/jpeg").Return(photo, nil)
+		photo.AnimalID = 3
+		photo.Position = 1 // after the photo the animal already has
+		mockAnimalStore.EXPECT().AddPhotos([]models.Photo{photo}).Return(nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).DoAndReturn(func(a *models.Animal) error {
+			assert.NotNil(t, a.ListingChangedAt, "new photos show the animal again")
+			assert.Len(t, a.Photos, 2)
+			return nil
+		})
+		mockPhotoService.EXPECT().DeletePhoto(key).Return(nil)
+
+		photos, err := service.ConfirmPhotoUploads(ownerID, "3", []string{key})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Photo{photo}, photos)
+	})
+
+	t.Run("Keys of another animal or already added", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil).Times(2)
+
+		_, err := service.ConfirmPhotoUploads(ownerID, "3", []string{"animals/4/uploads/new.jpg"})
+		assert.ErrorIs(t, err, services.ErrInvalidUploadKey)
+		_, err = service.ConfirmPhotoUploads(ownerID, "3", []string{"animals/3/uploads/old.jpg"})
+		assert.ErrorIs(t, err, services.ErrInvalidUploadKey)
+	})
+
+	t.Run("Not uploaded", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockPhotoService.EXPECT().GetPhotoInfo(key).Return(storage.Object{}, storage.ErrNotFound)
+
+		_, err := service.ConfirmPhotoUploads(ownerID, "3", []string{key})
+		assert.ErrorIs(t, err, services.ErrUploadNotFound)
+	})
+
+	t.Run("Uploaded file is over the limits", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil).Times(2)
+		mockPhotoService.EXPECT().GetPhotoInfo(key).Return(storage.Object{Size: constants.MaxPhotoSize + 1, ContentType: "image/jpeg"}, nil)
+		mockPhotoService.EXPECT().GetPhotoInfo(key).Return(storage.Object{Size: 1024, ContentType: "image/jpeg"}, nil)
+		mockPhotoService.EXPECT().ProcessUploadedPhoto(key, "image/jpeg").Return(models.Photo{}, uploads.ErrMalformedImage)
+
+		_, err := service.ConfirmPhotoUploads(ownerID, "3", []string{key})
+		assert.ErrorIs(t, err, uploads.ErrFileTooLarge)
+		_, err = service.ConfirmPhotoUploads(ownerID, "3", []string{key})
+		assert.ErrorIs(t, err, uploads.ErrMalformedImage)
+	})
+}
+
+func TestAnimalService_AddPhotosWithFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	ownerID := uuid.New()
+	animal := models.Animal{Name: "Luna", OwnerID: &ownerID, Photos: []models.Photo{{Key: "old.jpg", Position: 4}}}
+	animal.ID = 3
+	// Photos are uploaded concurrently, so each is named after its content.
+	upload := func(file io.Reader, prefix string) (models.Photo, error) {
+		data, err := io.ReadAll(file)
+		return models.Photo{Key: string(data) + ".jpg"}, err
+	}
+
+	t.Run("Photos are added after the existing ones", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "animals/3/photo").DoAndReturn(upload).Times(2)
+		mockAnimalStore.EXPECT().AddPhotos([]models.Photo{
+			{AnimalID: 3, Key: "a.jpg", Position: 5},
+			{AnimalID: 3, Key: "b.jpg", Position: 6},
+		}).Return(nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).Return(nil)
+
+		files := multipartFiles(t, 10, [2]string{"photos", "a"}, [2]string{"photos", "b"})
+		photos, err := service.AddPhotosWithFiles(ownerID, "3", files)
+		assert.NoError(t, err)
+		assert.Len(t, photos, 2)
+	})
+
+	t.Run("Stored photos are deleted when they can not be added", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "animals/3/photo").DoAndReturn(upload)
+		mockAnimalStore.EXPECT().AddPhotos(gomock.Any()).Return(fmt.Errorf("db is down"))
+		mockPhotoService.EXPECT().DeletePhoto("a.jpg").Return(nil)
+
+		_, err := service.AddPhotosWithFiles(ownerID, "3", multipartFiles(t, 10, [2]string{"photos", "a"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("Only photos are accepted", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil).Times(2)
+
+		_, err := service.AddPhotosWithFiles(ownerID, "3", multipartFiles(t, 10, [2]string{"image", "a"}))
+		assert.ErrorIs(t, err, uploads.ErrUnexpectedPart)
+		_, err = service.AddPhotosWithFiles(ownerID, "3", multipartFiles(t, 10))
+		assert.ErrorIs(t, err, uploads.ErrMissingPart)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+
+		_, err := service.AddPhotosWithFiles(uuid.New(), "3", multipartFiles(t, 10, [2]string{"photos", "a"}))
+		assert.ErrorIs(t, err, services.ErrNotAnimalOwner)
+	})
+}
+
+func TestAnimalService_ManagePhotos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+	ownerID := uuid.New()
+
+	photo := func(id uint, key string, position int) models.Photo {
+		p := models.Photo{AnimalID: 3, Key: key + "_full.jpg", Position: position, Variants: models.ImageVariants{ThumbKey: key + "_thumb.jpg"}}
+		p.ID = id
+		return p
+	}
+	newAnimal := func() models.Animal {
+		animal := models.Animal{
+			OwnerID: &ownerID,
+			Image:   models.Image{AnimalID: 3, Key: "b_full.jpg", Variants: models.ImageVariants{ThumbKey: "b_thumb.jpg"}},
+			Photos:  []models.Photo{photo(1, "a", 0), photo(2, "b", 1), photo(3, "c", 2)},
+		}
+		animal.ID = 3
+		animal.Image.ID = 9
+		return animal
+	}
+
+	t.Run("Deleted photo files are removed from storage", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+		mockAnimalStore.EXPECT().DeletePhoto(photo(1, "a", 0)).Return(nil)
+		mockPhotoService.EXPECT().DeletePhoto("a_full.jpg").Return(nil)
+		mockPhotoService.EXPECT().DeletePhoto("a_thumb.jpg").Return(nil)
+
+		assert.NoError(t, service.DeletePhoto(ownerID, "3", "1"))
+	})
+
+	t.Run("Files of the cover image are kept", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+		mockAnimalStore.EXPECT().DeletePhoto(photo(2, "b", 1)).Return(nil)
+
+		assert.NoError(t, service.DeletePhoto(ownerID, "3", "2"))
+	})
+
+	t.Run("Photo of another animal", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+
+		assert.ErrorIs(t, service.DeletePhoto(ownerID, "3", "7"), services.ErrPhotoNotFound)
+	})
+
+	t.Run("Only the owner manages photos", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil).Times(3)
+		stranger := uuid.New()
+
+		assert.ErrorIs(t, service.DeletePhoto(stranger, "3", "1"), services.ErrNotAnimalOwner)
+		_, err := service.ReorderPhotos(stranger, "3", []models.PhotoPositionJSON{{ID: 1, Position: 2}})
+		assert.ErrorIs(t, err, services.ErrNotAnimalOwner)
+		_, err = service.SetCover(stranger, "3", 1)
+		assert.ErrorIs(t, err, services.ErrNotAnimalOwner)
+	})
+
+	t.Run("Photos are reordered", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+		mockAnimalStore.EXPECT().UpdatePhotoPositions([]models.Photo{photo(3, "c", 0), photo(1, "a", 5)}).Return(nil)
+
+		animal, err := service.ReorderPhotos(ownerID, "3", []models.PhotoPositionJSON{{ID: 3, Position: 0}, {ID: 1, Position: 5}})
+		assert.NoError(t, err)
+		// c moved to the front and a to the back.
+		var keys []string
+		for _, p := range animal.Photos {
+			keys = append(keys, p.Key)
+		}
+		assert.Equal(t, []string{"c_full.jpg", "b_full.jpg", "a_full.jpg"}, keys)
+	})
+
+	t.Run("Reordering photos of another animal", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+
+		_, err := service.ReorderPhotos(ownerID, "3", []models.PhotoPositionJSON{{ID: 7, Position: 0}})
+		assert.ErrorIs(t, err, services.ErrPhotoNotFound)
+	})
+
+	t.Run("Cover is chosen from the photos", func(t *testing.T) {
+		animal := newAnimal()
+		animal.Image = models.Image{AnimalID: 3, Key: "cover_full.jpg", Variants: models.ImageVariants{CardKey: "cover_card.jpg"}}
+		animal.Image.ID = 9
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockAnimalStore.EXPECT().SaveImage(gomock.Any()).DoAndReturn(func(image *models.Image) error {
+			assert.Equal(t, uint(9), image.ID)
+			assert.Equal(t, "c_full.jpg", image.Key)
+			assert.Equal(t, "c_thumb.jpg", image.Variants.ThumbKey)
+			return nil
+		})
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).Return(nil)
+		// The previous cover was not one of the photos.
+		mockPhotoService.EXPECT().DeletePhoto("cover_full.jpg").Return(nil)
+		mockPhotoService.EXPECT().DeletePhoto("cover_card.jpg").Return(nil)
+
+		updated, err := service.SetCover(ownerID, "3", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "c_full.jpg", updated.Image.Key)
+		assert.NotNil(t, updated.ListingChangedAt)
+	})
+
+	t.Run("Previous cover that is a photo is kept", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(newAnimal(), nil)
+		mockAnimalStore.EXPECT().SaveImage(gomock.Any()).Return(nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).Return(nil)
+
+		_, err := service.SetCover(ownerID, "3", 1)
+		assert.NoError(t, err)
+	})
+}
+
+// multipartFiles streams the files, given as field and content pairs, from
+// a multipart request.
+func multipartFiles(t *testing.T, maxFileSize int64, files ...[2]string) *uploads.MultipartReader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, f := range files {
+		w, err := writer.CreateFormFile(f[0], f[0]+".jpg")
+		assert.NoError(t, err)
+		io.WriteString(w, f[1])
+	}
+	assert.NoError(t, writer.Close())
+
+	r, _ := http.NewRequest("POST", "/animal", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	reader, err := uploads.NewMultipartReader(r, maxFileSize, 10)
+	assert.NoError(t, err)
+	return reader
+}
+
 func TestAnimalService_GetAnimals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	userID := uuid.New()
 	ginContext := &gin.Context{}
@@ -84,8 +557,8 @@ func TestAnimalService_GetAllAnimals(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	ginContext := &gin.Context{}
 
@@ -106,8 +579,8 @@ func TestAnimalService_GetAnimalById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	animalID := uuid.New().String()
 
@@ -125,26 +598,75 @@ func TestAnimalService_MarkAsSeen(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	animalID := "1"
 	userID := uuid.New()
 	like := true
 
-	mockAnimalStore.EXPECT().MarkAsSeen(uint(1), userID, like).Return(nil)
+	mockAnimalStore.EXPECT().MarkAsSeen(uint(1), userID, like, "ios/2.3.0").Return(true, nil)
 
-	err := service.MarkAsSeen(animalID, userID, like)
+	err := service.MarkAsSeen(animalID, userID, like, "ios/2.3.0")
 	assert.NoError(t, err)
 }
 
+func TestAnimalService_MarkAsSeen_Webhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockWebhooks := mocks.NewMockWebhookProducerI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mocks.NewMockPhotoServiceI(ctrl), nil, nil, nil, mockWebhooks)
+	userID := uuid.New()
+	ownerID := uuid.New()
+
+	t.Run("Like that adds a favorite", func(t *testing.T) {
+		mockAnimalStore.EXPECT().MarkAsSeen(uint(7), userID, true, "ios/2.3.0").Return(true, nil)
+		mockAnimalStore.EXPECT().GetById("7").Return(models.Animal{Model: gorm.Model{ID: 7}, OwnerID: &ownerID}, nil)
+		mockWebhooks.EXPECT().Enqueue(ownerID, webhooks.EventAnimalLiked, gin.H{"animalId": uint(7)}).Return(nil)
+
+		assert.NoError(t, service.MarkAsSeen("7", userID, true, "ios/2.3.0"))
+	})
+
+	t.Run("Like of a favorite", func(t *testing.T) {
+		// The animal was a favorite already, its owner heard of it then.
+		mockAnimalStore.EXPECT().MarkAsSeen(uint(7), userID, true, "ios/2.3.0").Return(false, nil)
+
+		assert.NoError(t, service.MarkAsSeen("7", userID, true, "ios/2.3.0"))
+	})
+}
+
+func TestAnimalService_NotifyLiked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockWebhooks := mocks.NewMockWebhookProducerI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mocks.NewMockPhotoServiceI(ctrl), nil, nil, nil, mockWebhooks)
+
+	t.Run("Owner is told", func(t *testing.T) {
+		ownerID := uuid.New()
+		mockAnimalStore.EXPECT().GetById("7").Return(models.Animal{Model: gorm.Model{ID: 7}, OwnerID: &ownerID}, nil)
+		mockWebhooks.EXPECT().Enqueue(ownerID, webhooks.EventAnimalLiked, gin.H{"animalId": uint(7)}).Return(nil)
+
+		service.NotifyLiked(7)
+	})
+
+	t.Run("Animal without owner", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("8").Return(models.Animal{Model: gorm.Model{ID: 8}}, nil)
+
+		service.NotifyLiked(8)
+	})
+}
+
 func TestAnimalService_GetLikedAnimals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
-	mockS3Service := mocks.NewMockS3ServiceI(ctrl)
-	service := services.NewAnimalService(mockAnimalStore, mockS3Service)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
 
 	userID := uuid.New()
 	ginContext := &gin.Context{}
@@ -160,3 +682,129 @@ func TestAnimalService_GetLikedAnimals(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAnimals, animals)
 }
+
+func TestAnimalService_UpdateAnimal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mockPhotoService, nil, nil, nil, nil)
+
+	ownerID := uuid.New()
+
+	t.Run("Material change bumps ListingChangedAt", func(t *testing.T) {
+		existing := models.Animal{Name: "Animal 1", Status: models.AnimalStatusReserved, OwnerID: &ownerID}
+		status := models.AnimalStatusAvailable
+
+		mockAnimalStore.EXPECT().GetById("1").Return(existing, nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+			assert.Equal(t, models.AnimalStatusAvailable, arg.Status)
+			assert.NotNil(t, arg.ListingChangedAt)
+			return nil
+		})
+
+		animal, err := service.UpdateAnimal(ownerID, "1", &models.AnimalUpdateJSON{Status: &status})
+		assert.NoError(t, err)
+		assert.Equal(t, models.AnimalStatusAvailable, animal.Status)
+	})
+
+	t.Run("Adopting does not show the listing again", func(t *testing.T) {
+		existing := models.Animal{Name: "Animal 1", Status: models.AnimalStatusAvailable, OwnerID: &ownerID}
+		status := models.AnimalStatusAdopted
+
+		mockAnimalStore.EXPECT().GetById("1").Return(existing, nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+			assert.Equal(t, models.AnimalStatusAdopted, arg.Status)
+			assert.Nil(t, arg.ListingChangedAt)
+			return nil
+		})
+
+		_, err := service.UpdateAnimal(ownerID, "1", &models.AnimalUpdateJSON{Status: &status})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Minor change keeps ListingChangedAt", func(t *testing.T) {
+		existing := models.Animal{Name: "Animal 1", Status: models.AnimalStatusAvailable, OwnerID: &ownerID}
+		description := "Loves long walks"
+
+		mockAnimalStore.EXPECT().GetById("1").Return(existing, nil)
+		mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).DoAndReturn(func(arg *models.Animal) error {
+			assert.Equal(t, description, arg.Description)
+			assert.Nil(t, arg.ListingChangedAt)
+			return nil
+		})
+
+		_, err := service.UpdateAnimal(ownerID, "1", &models.AnimalUpdateJSON{Description: &description})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Only the owner updates the listing", func(t *testing.T) {
+		existing := models.Animal{Name: "Animal 1", Status: models.AnimalStatusAvailable, OwnerID: &ownerID}
+		status := models.AnimalStatusAdopted
+
+		mockAnimalStore.EXPECT().GetById("1").Return(existing, nil)
+
+		_, err := service.UpdateAnimal(uuid.New(), "1", &models.AnimalUpdateJSON{Status: &status})
+		assert.ErrorIs(t, err, services.ErrNotAnimalOwner)
+	})
+}
+
+func TestAnimalService_UpdateAnimalNotifiesLikers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockNotifications := mocks.NewMockNotificationProducerI(ctrl)
+	service := services.NewAnimalService(mockAnimalStore, mocks.NewMockUserStoreI(ctrl), mocks.NewMockPhotoServiceI(ctrl), nil, mockNotifications, nil, nil)
+
+	ownerID := uuid.New()
+	existing := models.Animal{Name: "Luna", Status: models.AnimalStatusAvailable, OwnerID: &ownerID}
+	existing.ID = 7
+	status := models.AnimalStatusAdopted
+	likers := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mockAnimalStore.EXPECT().GetById("7").Return(existing, nil)
+	mockAnimalStore.EXPECT().UpdateAnimal(gomock.Any()).Return(nil)
+	mockAnimalStore.EXPECT().GetFavoritedBy(uint(7)).Return(likers, nil)
+	mockNotifications.EXPECT().NotifyUsers(likers, gomock.Any()).DoAndReturn(func(_ []uuid.UUID, n models.Notification) error {
+		assert.Equal(t, models.NotificationTypeAnimalStatus, n.Type)
+		assert.Equal(t, "Luna was adopted", n.Title)
+		assert.Equal(t, uint(7), *n.AnimalID)
+		return nil
+	})
+
+	_, err := service.UpdateAnimal(ownerID, "7", &models.AnimalUpdateJSON{Status: &status})
+	assert.NoError(t, err)
+}
+
+func TestAnimalService_GetAnimalsRanked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockUserStore := mocks.NewMockUserStoreI(ctrl)
+	ranker := ranking.NewRanker(ranking.WeightedScorer{Scorer: ranking.PreferenceScorer{}, Weight: 1})
+	service := services.NewAnimalService(mockAnimalStore, mockUserStore, mocks.NewMockPhotoServiceI(ctrl), ranker, nil, nil, nil)
+
+	userID := uuid.New()
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request, _ = http.NewRequest("GET", "/animal?page_size=2", nil)
+
+	candidates := []models.Animal{
+		{Name: "Rex", Type: "dog"},
+		{Name: "Tom", Type: "cat"},
+		{Name: "Kitty", Type: "cat"},
+	}
+	mockAnimalStore.EXPECT().GetFeedCandidates(userID, ginContext, constants.FeedCandidatePoolSize).Return(candidates, nil)
+	mockUserStore.EXPECT().GetUserSettings(userID).Return(models.UserSettings{}, nil)
+	mockAnimalStore.EXPECT().GetSwipeSignals(userID, constants.FeedSignalHistorySize).Return([]ranking.Signal{
+		{AnimalType: "cat", Liked: true},
+		{AnimalType: "dog", Liked: false},
+	}, nil)
+
+	animals, err := service.GetAnimals(userID, ginContext)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Animal{candidates[1], candidates[2]}, animals)
+	assert.Equal(t, 2, ginContext.GetInt("totalPages"))
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/conversations"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoListingOwner   = errors.New("animal has no owner to message")
+	ErrOwnListing       = errors.New("cant start a conversation about your own animal")
+	ErrBlocked          = errors.New("messaging between these users is blocked")
+	ErrRateLimited      = errors.New("too many requests, try again later")
+	ErrNotParticipating = errors.New("not a participant of the conversation")
+)
+
+type ConversationServiceI interface {
+	StartConversation(adopterID uuid.UUID, animalID string) (models.Conversation, error)
+	GetConversations(userID uuid.UUID, c *gin.Context) ([]models.Conversation, error)
+	GetMessages(userID uuid.UUID, conversationID string, c *gin.Context) ([]models.Message, models.Conversation, error)
+	SendMessage(userID uuid.UUID, conversationID string, message *models.MessageSendJSON) (models.Message, models.Conversation, error)
+	MarkAsRead(userID uuid.UUID, conversationID string) error
+	BlockParticipant(userID uuid.UUID, conversationID string) error
+	ReportParticipant(userID uuid.UUID, conversationID string, report *models.ReportJSON) error
+}
+
+type ConversationService struct {
+	store        conversations.ConversationStoreI
+	animalStore  animals.AnimalStoreI
+	photoService photos.PhotoServiceI
+	// events may be nil, in which case messages are not streamed.
+	events events.PublisherI
+}
+
+func NewConversationService(store conversations.ConversationStoreI, animalStore animals.AnimalStoreI, photoService photos.PhotoServiceI, publisher events.PublisherI) *ConversationService {
+	return &ConversationService{
+		store:        store,
+		animalStore:  animalStore,
+		photoService: photoService,
+		events:       publisher,
+	}
+}
+
+// StartConversation returns the adopter's conversation with the owner of the
+// animal, starting one if there is none yet.
+func (s *ConversationService) StartConversation(adopterID uuid.UUID, animalID string) (models.Conversation, error) {
+	animal, err := s.animalStore.GetById(animalID)
+	if err != nil {
+		return models.Conversation{}, err
+	}
+	if animal.OwnerID == nil {
+		return models.Conversation{}, ErrNoListingOwner
+	}
+	if *animal.OwnerID == adopterID {
+		return models.Conversation{}, ErrOwnListing
+	}
+
+	conversation, err := s.store.FindConversation(animal.ID, adopterID)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return conversation, err
+	}
+
+	if err := s.checkBlocked(adopterID, *animal.OwnerID); err != nil {
+		return models.Conversation{}, err
+	}
+	started, err := s.store.CountConversationsSince(adopterID, time.Now().Add(-constants.ConversationRateWindow))
+	if err != nil {
+		return models.Conversation{}, err
+	}
+	if started >= constants.ConversationRateLimit {
+		return models.Conversation{}, ErrRateLimited
+	}
+
+	conversation = models.Conversation{
+		AnimalID:      animal.ID,
+		AdopterID:     adopterID,
+		OwnerID:       *animal.OwnerID,
+		LastMessageAt: time.Now(),
+	}
+	if err := s.store.CreateConversation(&conversation); err != nil {
+		return models.Conversation{}, err
+	}
+	return conversation, nil
+}
+
+func (s *ConversationService) GetConversations(userID uuid.UUID, c *gin.Context) ([]models.Conversation, error) {
+	return s.store.GetConversations(userID, c)
+}
+
+func (s *ConversationService) GetMessages(userID uuid.UUID, conversationID string, c *gin.Context) ([]models.Message, models.Conversation, error) {
+	conversation, err := s.conversationOf(userID, conversationID)
+	if err != nil {
+		return nil, models.Conversation{}, err
+	}
+
+	messages, err := s.store.GetMessages(conversation.ID, c)
+	if err != nil {
+		return nil, models.Conversation{}, err
+	}
+	return messages, conversation, nil
+}
+
+// SendMessage sends the message to the other participant, uploading its
+// attachment first.
+func (s *ConversationService) SendMessage(userID uuid.UUID, conversationID string, body *models.MessageSendJSON) (models.Message, models.Conversation, error) {
+	conversation, err := s.conversationOf(userID, conversationID)
+	if err != nil {
+		return models.Message{}, models.Conversation{}, err
+	}
+	recipient := conversation.Other(userID)
+
+	if err := s.checkBlocked(userID, recipient); err != nil {
+		return models.Message{}, models.Conversation{}, err
+	}
+	sent, err := s.store.CountMessagesSince(userID, time.Now().Add(-constants.MessageRateWindow))
+	if err != nil {
+		return models.Message{}, models.Conversation{}, err
+	}
+	if sent >= constants.MessageRateLimit {
+		return models.Message{}, models.Conversation{}, ErrRateLimited
+	}
+
+	message := models.Message{ConversationID: conversation.ID, SenderID: userID, Body: body.Body}
+	if body.Attachment != "" {
+		photo, err := s.photoService.UploadSinglePhoto(body.Attachment, "message_"+strconv.FormatUint(uint64(conversation.ID), 10))
+		if err != nil {
+			return models.Message{}, models.Conversation{}, err
+		}
+		message.AttachmentURL = photo.ImageURL
+		message.AttachmentKey = photo.Key
+		message.AttachmentVariants = photo.Variants
+	}
+
+	if err := s.store.AddMessage(&message); err != nil {
+		return models.Message{}, models.Conversation{}, err
+	}
+
+	if s.events != nil {
+		data := gin.H{"conversationId": conversation.ID, "message": models.ToMessageJSON(message, conversation, recipient)}
+		if err := s.events.Publish(events.TopicMessage, []uuid.UUID{recipient}, data); err != nil {
+			log.Error().Err(err).Uint("messageID", message.ID).Msg("cant publish message")
+		}
+	}
+	return message, conversation, nil
+}
+
+func (s *ConversationService) MarkAsRead(userID uuid.UUID, conversationID string) error {
+	conversation, err := s.conversationOf(userID, conversationID)
+	if err != nil {
+		return err
+	}
+	return s.store.MarkAsRead(conversation, userID)
+}
+
+// BlockParticipant stops the other participant from messaging the user in
+// any conversation.
+func (s *ConversationService) BlockParticipant(userID uuid.UUID, conversationID string) error {
+	conversation, err := s.conversationOf(userID, conversationID)
+	if err != nil {
+		return err
+	}
+	return s.store.Block(userID, conversation.Other(userID))
+}
+
+func (s *ConversationService) ReportParticipant(userID uuid.UUID, conversationID string, report *models.ReportJSON) error {
+	conversation, err := s.conversationOf(userID, conversationID)
+	if err != nil {
+		return err
+	}
+	return s.store.AddReport(&models.Report{
+		ReporterID:     userID,
+		ReportedID:     conversation.Other(userID),
+		ConversationID: conversation.ID,
+		Reason:         report.Reason,
+	})
+}
+
+// conversationOf loads the conversation, hiding it from non-participants.
+func (s *ConversationService) conversationOf(userID uuid.UUID, conversationID string) (models.Conversation, error) {
+	id, err := strconv.ParseUint(conversationID, 10, 64)
+	if err != nil {
+		return models.Conversation{}, err
+	}
+
+	conversation, err := s.store.GetConversation(uint(id))
+	if err != nil {
+		return models.Conversation{}, err
+	}
+	if !conversation.Participant(userID) {
+		return models.Conversation{}, ErrNotParticipating
+	}
+	return conversation, nil
+}
+
+func (s *ConversationService) checkBlocked(a, b uuid.UUID) error {
+	blocked, err := s.store.IsBlocked(a, b)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestConversationService_StartConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockConversationStoreI(ctrl)
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	service := services.NewConversationService(mockStore, mockAnimalStore, nil, nil)
+
+	adopter, owner := uuid.New(), uuid.New()
+	animal := models.Animal{OwnerID: &owner}
+	animal.ID = 3
+
+	t.Run("Successful StartConversation", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockStore.EXPECT().FindConversation(uint(3), adopter).Return(models.Conversation{}, gorm.ErrRecordNotFound)
+		mockStore.EXPECT().IsBlocked(adopter, owner).Return(false, nil)
+		mockStore.EXPECT().CountConversationsSince(adopter, gomock.Any()).Return(int64(0), nil)
+		mockStore.EXPECT().CreateConversation(gomock.Any()).Return(nil)
+
+		conversation, err := service.StartConversation(adopter, "3")
+		assert.NoError(t, err)
+		assert.Equal(t, adopter, conversation.AdopterID)
+		assert.Equal(t, owner, conversation.OwnerID)
+	})
+
+	t.Run("Existing conversation is returned", func(t *testing.T) {
+		existing := models.Conversation{ID: 8, AnimalID: 3, AdopterID: adopter, OwnerID: owner}
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockStore.EXPECT().FindConversation(uint(3), adopter).Return(existing, nil)
+
+		conversation, err := service.StartConversation(adopter, "3")
+		assert.NoError(t, err)
+		assert.Equal(t, existing, conversation)
+	})
+
+	t.Run("Own listing", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+
+		_, err := service.StartConversation(owner, "3")
+		assert.ErrorIs(t, err, services.ErrOwnListing)
+	})
+
+	t.Run("Too many conversations", func(t *testing.T) {
+		mockAnimalStore.EXPECT().GetById("3").Return(animal, nil)
+		mockStore.EXPECT().FindConversation(uint(3), adopter).Return(models.Conversation{}, gorm.ErrRecordNotFound)
+		mockStore.EXPECT().IsBlocked(adopter, owner).Return(false, nil)
+		mockStore.EXPECT().CountConversationsSince(adopter, gomock.Any()).Return(int64(constants.ConversationRateLimit), nil)
+
+		_, err := service.StartConversation(adopter, "3")
+		assert.ErrorIs(t, err, services.ErrRateLimited)
+	})
+}
+
+func TestConversationService_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockConversationStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	mockPublisher := mocks.NewMockPublisherI(ctrl)
+	service := services.NewConversationService(mockStore, mocks.NewMockAnimalStoreI(ctrl), mockPhotoService, mockPublisher)
+
+	adopter, owner := uuid.New(), uuid.New()
+	conversation := models.Conversation{ID: 8, AnimalID: 3, AdopterID: adopter, OwnerID: owner}
+
+	t.Run("Message with attachment is sent and streamed", func(t *testing.T) {
+		mockStore.EXPECT().GetConversation(uint(8)).Return(conversation, nil)
+		mockStore.EXPECT().IsBlocked(adopter, owner).Return(false, nil)
+		mockStore.EXPECT().CountMessagesSince(adopter, gomock.Any()).Return(int64(0), nil)
+		mockPhotoService.EXPECT().UploadSinglePhoto("data:image/jpeg;base64,AAAA", "message_8").Return(models.Photo{
+			ImageURL: "https://s3.amazonaws.com/findyourpet-kach/message_8.jpg",
+			Key:      "message_8.jpg",
+			Variants: models.ImageVariants{ThumbKey: "message_8_thumb.jpg", CardKey: "message_8_card.jpg"},
+		}, nil)
+		mockStore.EXPECT().AddMessage(gomock.Any()).DoAndReturn(func(m *models.Message) error {
+			assert.Equal(t, "message_8.jpg", m.AttachmentKey)
+			// The variants are kept, or media gc would delete them.
+			assert.Equal(t, []string{"message_8.jpg", "message_8_thumb.jpg", "message_8_card.jpg"}, m.AttachmentKeys())
+			m.ID = 21
+			return nil
+		})
+		mockPublisher.EXPECT().Publish(events.TopicMessage, []uuid.UUID{owner}, gomock.Any()).Return(nil)
+
+		message, _, err := service.SendMessage(adopter, "8", &models.MessageSendJSON{Body: "Is she good with cats?", Attachment: "data:image/jpeg;base64,AAAA"})
+		assert.NoError(t, err)
+		assert.Equal(t, uint(21), message.ID)
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		mockStore.EXPECT().GetConversation(uint(8)).Return(conversation, nil)
+		mockStore.EXPECT().IsBlocked(owner, adopter).Return(true, nil)
+
+		_, _, err := service.SendMessage(owner, "8", &models.MessageSendJSON{Body: "Hi"})
+		assert.ErrorIs(t, err, services.ErrBlocked)
+	})
+
+	t.Run("Too many messages", func(t *testing.T) {
+		mockStore.EXPECT().GetConversation(uint(8)).Return(conversation, nil)
+		mockStore.EXPECT().IsBlocked(adopter, owner).Return(false, nil)
+		mockStore.EXPECT().CountMessagesSince(adopter, gomock.Any()).Return(int64(constants.MessageRateLimit), nil)
+
+		_, _, err := service.SendMessage(adopter, "8", &models.MessageSendJSON{Body: "Hi"})
+		assert.ErrorIs(t, err, services.ErrRateLimited)
+	})
+
+	t.Run("Not a participant", func(t *testing.T) {
+		mockStore.EXPECT().GetConversation(uint(8)).Return(conversation, nil)
+
+		_, _, err := service.SendMessage(uuid.New(), "8", &models.MessageSendJSON{Body: "Hi"})
+		assert.ErrorIs(t, err, services.ErrNotParticipating)
+	})
+}
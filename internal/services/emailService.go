@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/retry"
+	"github.com/rs/zerolog/log"
+)
+
+// EmailService sends the emails queued in the outbox.
+type EmailService struct {
+	store  outbox.OutboxStoreI
+	mailer mail.MailerI
+}
+
+func NewEmailService(store outbox.OutboxStoreI, mailer mail.MailerI) *EmailService {
+	return &EmailService{store: store, mailer: mailer}
+}
+
+// Start sends the due emails on every tick until the context is done.
+func (s *EmailService) Start(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if err := s.DispatchDue(time.Now()); err != nil {
+			log.Error().Err(err).Msg("email dispatch failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends the pending emails that are due. Temporary failures are
+// retried with exponential backoff; a permanent rejection marks the email as
+// bounced and suppresses further emails to the address.
+func (s *EmailService) DispatchDue(now time.Time) error {
+	for {
+		emails, err := s.store.ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease)
+		if err != nil {
+			return err
+		}
+
+		for i := range emails {
+			if err := s.attempt(&emails[i], now); err != nil {
+				return err
+			}
+			if err := s.store.UpdateEmail(&emails[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(emails) < constants.EmailBatchSize {
+			return nil
+		}
+	}
+}
+
+// attempt sends the email once and records the outcome on it.
+func (s *EmailService) attempt(email *models.OutboxEmail, now time.Time) error {
+	suppressed, err := s.store.IsSuppressed(email.Recipient)
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		email.Status = models.EmailSuppressed
+		return nil
+	}
+
+	email.Attempts++
+	err = s.mailer.Send(mail.Email{To: email.Recipient, Subject: email.Subject, Text: email.Text, HTML: email.HTML})
+	if err == nil {
+		email.Status = models.EmailSent
+		email.LastError = ""
+		email.SentAt = &now
+		return nil
+	}
+
+	email.LastError = err.Error()
+	if mail.IsPermanent(err) {
+		email.Status = models.EmailBounced
+		return s.store.Suppress(email.Recipient, email.LastError)
+	}
+	if email.Attempts >= constants.EmailMaxAttempts {
+		email.Status = models.EmailFailed
+		return nil
+	}
+	email.NextAttemptAt = now.Add(retry.Backoff(email.Attempts, constants.EmailRetryBase, constants.EmailRetryMax))
+	return nil
+}
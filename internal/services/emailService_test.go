@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"errors"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailService_DispatchDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockOutboxStoreI(ctrl)
+	mockMailer := mocks.NewMockMailerI(ctrl)
+	service := services.NewEmailService(mockStore, mockMailer)
+	now := time.Now()
+
+	pending := func(attempts int) models.OutboxEmail {
+		return models.OutboxEmail{ID: 1, Recipient: "user@example.com", Subject: "Hello", Text: "Hi", Status: models.EmailPending, Attempts: attempts}
+	}
+
+	t.Run("Sent", func(t *testing.T) {
+		mockStore.EXPECT().ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease).Return([]models.OutboxEmail{pending(0)}, nil)
+		mockStore.EXPECT().IsSuppressed("user@example.com").Return(false, nil)
+		mockMailer.EXPECT().Send(mail.Email{To: "user@example.com", Subject: "Hello", Text: "Hi"}).Return(nil)
+		mockStore.EXPECT().UpdateEmail(gomock.Any()).DoAndReturn(func(email *models.OutboxEmail) error {
+			assert.Equal(t, models.EmailSent, email.Status)
+			assert.Equal(t, 1, email.Attempts)
+			assert.NotNil(t, email.SentAt)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Temporary failure is retried with backoff", func(t *testing.T) {
+		mockStore.EXPECT().ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease).Return([]models.OutboxEmail{pending(1)}, nil)
+		mockStore.EXPECT().IsSuppressed("user@example.com").Return(false, nil)
+		mockMailer.EXPECT().Send(gomock.Any()).Return(&textproto.Error{Code: 421, Msg: "Try again later"})
+		mockStore.EXPECT().UpdateEmail(gomock.Any()).DoAndReturn(func(email *models.OutboxEmail) error {
+			assert.Equal(t, models.EmailPending, email.Status)
+			assert.Equal(t, 2, email.Attempts)
+			assert.Equal(t, now.Add(2*constants.EmailRetryBase), email.NextAttemptAt)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Fails after the last attempt", func(t *testing.T) {
+		mockStore.EXPECT().ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease).Return([]models.OutboxEmail{pending(constants.EmailMaxAttempts - 1)}, nil)
+		mockStore.EXPECT().IsSuppressed("user@example.com").Return(false, nil)
+		mockMailer.EXPECT().Send(gomock.Any()).Return(errors.New("connection refused"))
+		mockStore.EXPECT().UpdateEmail(gomock.Any()).DoAndReturn(func(email *models.OutboxEmail) error {
+			assert.Equal(t, models.EmailFailed, email.Status)
+			assert.Equal(t, "connection refused", email.LastError)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Bounce suppresses the address", func(t *testing.T) {
+		mockStore.EXPECT().ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease).Return([]models.OutboxEmail{pending(0)}, nil)
+		mockStore.EXPECT().IsSuppressed("user@example.com").Return(false, nil)
+		mockMailer.EXPECT().Send(gomock.Any()).Return(&textproto.Error{Code: 550, Msg: "No such user"})
+		mockStore.EXPECT().Suppress("user@example.com", `550 "No such user"`).Return(nil)
+		mockStore.EXPECT().UpdateEmail(gomock.Any()).DoAndReturn(func(email *models.OutboxEmail) error {
+			assert.Equal(t, models.EmailBounced, email.Status)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Suppressed address is skipped", func(t *testing.T) {
+		mockStore.EXPECT().ClaimDueEmails(now, constants.EmailBatchSize, constants.EmailLease).Return([]models.OutboxEmail{pending(0)}, nil)
+		mockStore.EXPECT().IsSuppressed("user@example.com").Return(true, nil)
+		mockStore.EXPECT().UpdateEmail(gomock.Any()).DoAndReturn(func(email *models.OutboxEmail) error {
+			assert.Equal(t, models.EmailSuppressed, email.Status)
+			assert.Equal(t, 0, email.Attempts)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserStore := mocks.NewMockUserStoreI(ctrl)
+	mockOutbox := mocks.NewMockOutboxStoreI(ctrl)
+	emails, err := mail.NewRenderer()
+	assert.NoError(t, err)
+	notifier := services.NewEmailNotifier(mockUserStore, mockOutbox, emails)
+
+	user := &models.User{ID: uuid.New(), Email: "user@example.com", Locale: "uk"}
+	mockUserStore.EXPECT().GetByID(user.ID).Return(user, nil)
+	mockOutbox.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(queued ...models.OutboxEmail) error {
+		assert.Len(t, queued, 1)
+		assert.Equal(t, "user@example.com", queued[0].Recipient)
+		assert.Equal(t, mail.TemplateNotification, queued[0].Template)
+		assert.Equal(t, "New matches", queued[0].Subject)
+		assert.Contains(t, queued[0].HTML, "Відкрити FindYourPet")
+		return nil
+	})
+
+	err = notifier.Notify(models.Notification{UserID: user.ID, Title: "New matches", Body: "- Luna", Link: "https://findyourpet.test/animal/all"})
+	assert.NoError(t, err)
+}
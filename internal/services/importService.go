@@ -0,0 +1,286 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFileReferencesNotSupported = errors.New("photos must be URLs, file references are not supported here")
+	ErrInvalidFileReference       = errors.New("invalid file reference")
+	ErrTooManyFetches             = errors.New("too many photo URLs")
+)
+
+type ImportServiceI interface {
+	ImportAnimals(ownerID uuid.UUID, r io.Reader, options ImportOptions) (models.ImportReportJSON, error)
+}
+
+type ImportOptions struct {
+	// Format is one of the imports formats.
+	Format string
+	// DryRun only validates the rows. Photos are not fetched.
+	DryRun bool
+	// MaxRows limits the rows of the import, unless it is 0.
+	MaxRows int
+	// MaxFetches limits the photos given by URL in all rows, unless it is
+	// 0. Rows past the limit fail.
+	MaxFetches int
+	// Files holds the photos that rows refer to by path. Without it only
+	// URLs are accepted.
+	Files fs.FS
+}
+
+// ImportService adds listings in bulk, as shelters keep them in
+// spreadsheets. Imported listings are not announced to subscribers, there
+// would be hundreds at once.
+type ImportService struct {
+	animalStore  animals.AnimalStoreI
+	photoService photos.PhotoServiceI
+	// client fetches the photos given by URL.
+	client *http.Client
+}
+
+func NewImportService(animalStore animals.AnimalStoreI, photoService photos.PhotoServiceI, client *http.Client) *ImportService {
+	return &ImportService{animalStore: animalStore, photoService: photoService, client: client}
+}
+
+// ImportAnimals validates every row with the rules of the API, then stores
+// the photos of the valid ones and inserts them in batches. Rows that fail
+// are reported and skipped. The error is only set when the import as a whole
+// can not be read.
+func (s *ImportService) ImportAnimals(ownerID uuid.UUID, r io.Reader, options ImportOptions) (models.ImportReportJSON, error) {
+	report := models.ImportReportJSON{DryRun: options.DryRun, Errors: []models.ImportRowErrorJSON{}}
+	rows, err := imports.Read(r, options.Format, options.MaxRows)
+	if err != nil {
+		return report, err
+	}
+	report.Rows = len(rows)
+
+	valid := make([]imports.Row, 0, len(rows))
+	fetches := 0
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = binding.Validator.ValidateStruct(&row.Animal)
+		}
+		if err == nil {
+			err = checkReferences(row.Animal, options.Files)
+		}
+		if err == nil && options.MaxFetches > 0 {
+			if urls := countURLs(row.Animal); fetches+urls > options.MaxFetches {
+				err = fmt.Errorf("%w, at most %d are downloaded per import", ErrTooManyFetches, options.MaxFetches)
+			} else {
+				fetches += urls
+			}
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, models.ImportRowErrorJSON{Row: row.Line, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, row)
+	}
+	report.Valid = len(valid)
+	if options.DryRun {
+		return report, nil
+	}
+
+	for start := 0; start < len(valid); start += constants.ImportBatchSize {
+		end := start + constants.ImportBatchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		s.importBatch(ownerID, valid[start:end], options.Files, &report)
+	}
+	return report, nil
+}
+
+// importBatch stores the photos of each row, then inserts the rows whose
+// photos could be stored together.
+func (s *ImportService) importBatch(ownerID uuid.UUID, rows []imports.Row, files fs.FS, report *models.ImportReportJSON) {
+	animals := make([]*models.Animal, 0, len(rows))
+	lines := make([]int, 0, len(rows))
+	batches := make([]*photoBatch, 0, len(rows))
+	for _, row := range rows {
+		a, batch, err := s.storePhotos(ownerID, row.Animal, files)
+		if err != nil {
+			report.Errors = append(report.Errors, models.ImportRowErrorJSON{Row: row.Line, Error: err.Error()})
+			continue
+		}
+		animals = append(animals, a)
+		lines = append(lines, row.Line)
+		batches = append(batches, batch)
+	}
+	if len(animals) == 0 {
+		return
+	}
+
+	inserted := make([]int, 0, len(animals))
+	if err := s.animalStore.AddAnimals(animals); err == nil {
+		for i := range animals {
+			inserted = append(inserted, i)
+		}
+	} else if len(animals) == 1 {
+		batches[0].Rollback()
+		report.Errors = append(report.Errors, models.ImportRowErrorJSON{Row: lines[0], Error: err.Error()})
+	} else {
+		// A single bad row fails the whole batch, so the rows are inserted
+		// one by one to only skip the bad ones.
+		for i, a := range animals {
+			resetIDs(a)
+			if err := s.animalStore.AddAnimals([]*models.Animal{a}); err != nil {
+				batches[i].Rollback()
+				report.Errors = append(report.Errors, models.ImportRowErrorJSON{Row: lines[i], Error: err.Error()})
+				continue
+			}
+			inserted = append(inserted, i)
+		}
+	}
+	report.Imported += len(inserted)
+
+	for _, i := range inserted {
+		a := animals[i]
+		if duplicates := flagDuplicates(s.animalStore, *a); len(duplicates) > 0 {
+			report.Warnings = append(report.Warnings, models.ImportRowWarningJSON{Row: lines[i], AnimalID: a.ID, Duplicates: duplicates})
+		}
+	}
+}
+
+// storePhotos stores the image and photos of the row concurrently. They are
+// deleted again if any of them fails.
+func (s *ImportService) storePhotos(ownerID uuid.UUID, animal models.AnimalJSON, files fs.FS) (*models.Animal, *photoBatch, error) {
+	a := models.FromAnimalJSON(&animal)
+	a.OwnerID = &ownerID
+
+	refs := animal.Photos
+	if animal.Image != "" {
+		refs = append([]string{animal.Image}, refs...)
+	}
+	batch := newPhotoBatch(s.photoService, constants.PhotoUploadWorkers)
+	for i, ref := range refs {
+		ref, prefix := ref, animal.Name
+		if i == 0 && animal.Image != "" {
+			prefix += "_image"
+		}
+		if !batch.Go(func() (models.Photo, error) { return s.upload(ref, prefix, files) }) {
+			break
+		}
+	}
+
+	stored, err := batch.Wait()
+	if err != nil {
+		batch.Rollback()
+		return nil, nil, err
+	}
+	for i, photo := range stored {
+		if i == 0 && animal.Image != "" {
+			a.Image = toImage(photo)
+			continue
+		}
+		photo.Position = len(a.Photos)
+		a.Photos = append(a.Photos, photo)
+	}
+	return a, batch, nil
+}
+
+// upload stores the photo the reference points to, a data URI as in the
+// API, a URL or a path in files.
+func (s *ImportService) upload(ref, prefix string, files fs.FS) (models.Photo, error) {
+	var data []byte
+	var err error
+	switch {
+	case isDataURI(ref):
+		return s.photoService.UploadSinglePhoto(ref, prefix)
+	case isURL(ref):
+		data, err = uploads.Fetch(s.client, ref, constants.MaxPhotoSize)
+	default:
+		data, err = readFile(files, ref)
+	}
+	if err != nil {
+		return models.Photo{}, fmt.Errorf("photo %s: %w", ref, err)
+	}
+	return s.photoService.UploadPhotoFromReader(bytes.NewReader(data), prefix)
+}
+
+// resetIDs clears the keys a failed insert may have assigned to the animal
+// and its media, so that it can be inserted again.
+func resetIDs(a *models.Animal) {
+	a.ID = 0
+	a.Image.ID, a.Image.AnimalID = 0, 0
+	for i := range a.Photos {
+		a.Photos[i].ID, a.Photos[i].AnimalID = 0, 0
+	}
+}
+
+// countURLs counts the photos of the row that are downloaded.
+func countURLs(animal models.AnimalJSON) int {
+	count := 0
+	if isURL(animal.Image) {
+		count++
+	}
+	for _, ref := range animal.Photos {
+		if isURL(ref) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkReferences checks the photo references of the row without fetching
+// them.
+func checkReferences(animal models.AnimalJSON, files fs.FS) error {
+	refs := animal.Photos
+	if animal.Image != "" {
+		refs = append([]string{animal.Image}, refs...)
+	}
+	if len(refs) > constants.MaxPhotos {
+		return fmt.Errorf("%w, at most %d photos", uploads.ErrTooManyFiles, constants.MaxPhotos)
+	}
+	for _, ref := range refs {
+		switch {
+		case isDataURI(ref), isURL(ref):
+		case files == nil:
+			return ErrFileReferencesNotSupported
+		case !fs.ValidPath(ref):
+			return fmt.Errorf("%w %q", ErrInvalidFileReference, ref)
+		}
+	}
+	return nil
+}
+
+func readFile(files fs.FS, name string) ([]byte, error) {
+	file, err := files.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, constants.MaxPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > constants.MaxPhotoSize {
+		return nil, uploads.ErrFileTooLarge
+	}
+	return data, nil
+}
+
+func isDataURI(ref string) bool {
+	return strings.HasPrefix(ref, "data:")
+}
+
+func isURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
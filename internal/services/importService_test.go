@@ -0,0 +1,207 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const importHeader = "name,age,type,description,gender,image,photos\n"
+
+// uploadContent stores photos under their content.
+func uploadContent(file io.Reader, prefix string) (models.Photo, error) {
+	data, err := io.ReadAll(file)
+	return models.Photo{Key: prefix + "/" + string(data)}, err
+}
+
+func TestImportService_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := services.NewImportService(mocks.NewMockAnimalStoreI(ctrl), mocks.NewMockPhotoServiceI(ctrl), http.DefaultClient)
+
+	data := importHeader +
+		"Luna,2,cat,Calm,FEMALE,https://example.com/luna.jpg,\n" +
+		"Max,2,dog,,MALE,,\n" +
+		"Rex,3,dog,Playful,MALE,rex.jpg,\n" +
+		"Bella,1,dog,Gentle,FEMALE,,../bella.jpg\n" +
+		"Milo,0.5,cat,Curious,MALE,," + strings.Repeat("https://example.com/milo.jpg ", constants.MaxPhotos+1) + "\n"
+
+	t.Run("Without files", func(t *testing.T) {
+		report, err := service.ImportAnimals(uuid.New(), strings.NewReader(data), services.ImportOptions{Format: imports.FormatCSV, DryRun: true})
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 5, report.Rows)
+		assert.Equal(t, 1, report.Valid)
+		assert.Equal(t, 0, report.Imported)
+
+		rows := make([]int, 0, len(report.Errors))
+		for _, e := range report.Errors {
+			rows = append(rows, e.Row)
+		}
+		assert.Equal(t, []int{3, 4, 5, 6}, rows)
+		assert.Contains(t, report.Errors[0].Error, "Description")
+		assert.Equal(t, services.ErrFileReferencesNotSupported.Error(), report.Errors[1].Error)
+		assert.Contains(t, report.Errors[3].Error, "too many files")
+	})
+
+	t.Run("With files", func(t *testing.T) {
+		report, err := service.ImportAnimals(uuid.New(), strings.NewReader(data), services.ImportOptions{
+			Format: imports.FormatCSV,
+			DryRun: true,
+			Files:  fstest.MapFS{},
+		})
+		assert.NoError(t, err)
+		// Files are only opened once the rows are imported.
+		assert.Equal(t, 2, report.Valid)
+		assert.Contains(t, report.Errors[1].Error, "invalid file reference")
+	})
+
+	t.Run("Unreadable import", func(t *testing.T) {
+		_, err := service.ImportAnimals(uuid.New(), strings.NewReader("name,breed\n"), services.ImportOptions{Format: imports.FormatCSV})
+		assert.ErrorIs(t, err, imports.ErrUnknownColumn)
+	})
+}
+
+func TestImportService_ImportAnimals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "fetched"+r.URL.Path)
+	}))
+	defer server.Close()
+	service := services.NewImportService(mockAnimalStore, mockPhotoService, server.Client())
+	files := fstest.MapFS{"photos/luna.jpg": {Data: []byte("file")}}
+	ownerID := uuid.New()
+
+	data := `{"name":"Luna","age":2,"type":"cat","description":"Calm","gender":"FEMALE","image":"data:image/png;base64,aW1hZ2U=","photos":["photos/luna.jpg","` + server.URL + `/luna.jpg"]}
+{"name":"Max","age":2,"type":"dog","description":"Playful","gender":"MALE","image":"` + server.URL + `/missing.jpg"}
+{"name":"Rex","age":3,"type":"dog","description":"Gentle","gender":"MALE"}
+`
+
+	hash := int64(42)
+	mockPhotoService.EXPECT().UploadSinglePhoto("data:image/png;base64,aW1hZ2U=", "Luna_image").
+		Return(models.Photo{Key: "Luna_image/image", PerceptualHash: &hash}, nil)
+	mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna").DoAndReturn(uploadContent).Times(2)
+	mockAnimalStore.EXPECT().AddAnimals(gomock.Any()).DoAndReturn(func(animals []*models.Animal) error {
+		assert.Len(t, animals, 2)
+		luna, rex := animals[0], animals[1]
+		assert.Equal(t, &ownerID, luna.OwnerID)
+		assert.Equal(t, "Luna_image/image", luna.Image.Key)
+		assert.Equal(t, []models.Photo{{Key: "Luna/file"}, {Key: "Luna/fetched/luna.jpg", Position: 1}}, luna.Photos)
+		assert.Equal(t, "Rex", rex.Name)
+		assert.Empty(t, rex.Image.Key)
+		luna.ID, rex.ID = 1, 2
+		return nil
+	})
+	mockAnimalStore.EXPECT().FindSimilarAnimals([]int64{42}, uint(1), constants.DuplicateHashDistance).
+		Return([]models.SimilarAnimal{{AnimalID: 9, Distance: 1}}, nil)
+	mockAnimalStore.EXPECT().AddModerationItems(gomock.Len(1)).Return(nil)
+
+	report, err := service.ImportAnimals(ownerID, strings.NewReader(data), services.ImportOptions{Format: imports.FormatJSONL, Files: files})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Rows)
+	assert.Equal(t, 3, report.Valid)
+	assert.Equal(t, 2, report.Imported)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 2, report.Errors[0].Row)
+	assert.Contains(t, report.Errors[0].Error, "404")
+	assert.Equal(t, []models.ImportRowWarningJSON{{Row: 1, AnimalID: 1, Duplicates: []models.DuplicateWarningJSON{{AnimalID: 9, Distance: 1}}}}, report.Warnings)
+}
+
+func TestImportService_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockPhotoService := mocks.NewMockPhotoServiceI(ctrl)
+	service := services.NewImportService(mockAnimalStore, mockPhotoService, http.DefaultClient)
+	files := fstest.MapFS{"luna.jpg": {Data: []byte("luna")}}
+
+	var data strings.Builder
+	data.WriteString(importHeader)
+	for i := 0; i < constants.ImportBatchSize; i++ {
+		data.WriteString("Rex,3,dog,Playful,MALE,,\n")
+	}
+	data.WriteString("Luna,2,cat,Calm,FEMALE,luna.jpg,\n")
+
+	// The first batch is inserted, the second one fails and the photo it
+	// stored is deleted again.
+	first := mockAnimalStore.EXPECT().AddAnimals(gomock.Len(constants.ImportBatchSize)).Return(nil)
+	mockPhotoService.EXPECT().UploadPhotoFromReader(gomock.Any(), "Luna_image").DoAndReturn(uploadContent)
+	mockAnimalStore.EXPECT().AddAnimals(gomock.Len(1)).Return(errors.New("db is down")).After(first)
+	mockPhotoService.EXPECT().DeletePhoto("Luna_image/luna").Return(nil)
+
+	report, err := service.ImportAnimals(uuid.New(), strings.NewReader(data.String()), services.ImportOptions{Format: imports.FormatCSV, Files: files})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportBatchSize+1, report.Valid)
+	assert.Equal(t, constants.ImportBatchSize, report.Imported)
+	assert.Equal(t, []models.ImportRowErrorJSON{{Row: constants.ImportBatchSize + 2, Error: "db is down"}}, report.Errors)
+}
+
+func TestImportService_MaxFetches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := services.NewImportService(mocks.NewMockAnimalStoreI(ctrl), mocks.NewMockPhotoServiceI(ctrl), http.DefaultClient)
+
+	data := importHeader +
+		"Luna,2,cat,Calm,FEMALE,https://example.com/luna.jpg,https://example.com/luna2.jpg\n" +
+		"Max,2,dog,Playful,MALE,https://example.com/max.jpg,\n" +
+		"Rex,3,dog,Gentle,MALE,,\n"
+
+	report, err := service.ImportAnimals(uuid.New(), strings.NewReader(data), services.ImportOptions{Format: imports.FormatCSV, DryRun: true, MaxFetches: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Valid)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 3, report.Errors[0].Row)
+	assert.Contains(t, report.Errors[0].Error, services.ErrTooManyFetches.Error())
+}
+
+func TestImportService_RetryRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	service := services.NewImportService(mockAnimalStore, mocks.NewMockPhotoServiceI(ctrl), http.DefaultClient)
+
+	data := importHeader +
+		"Luna,2,cat,Calm,FEMALE,,\n" +
+		"Max,2,dog,Playful,MALE,,\n" +
+		"Rex,3,dog,Gentle,MALE,,\n"
+
+	// The batch fails on Max, the others are inserted on their own.
+	batch := mockAnimalStore.EXPECT().AddAnimals(gomock.Len(3)).DoAndReturn(func(animals []*models.Animal) error {
+		animals[0].ID = 1
+		return errors.New("duplicate key")
+	})
+	mockAnimalStore.EXPECT().AddAnimals(gomock.Len(1)).DoAndReturn(func(animals []*models.Animal) error {
+		if animals[0].Name == "Max" {
+			return errors.New("duplicate key")
+		}
+		assert.Zero(t, animals[0].ID, "keys of the failed batch are cleared")
+		return nil
+	}).Times(3).After(batch)
+
+	report, err := service.ImportAnimals(uuid.New(), strings.NewReader(data), services.ImportOptions{Format: imports.FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []models.ImportRowErrorJSON{{Row: 3, Error: "duplicate key"}}, report.Errors)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/media"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// MediaGCOptions choose what a garbage collection run looks at.
+type MediaGCOptions struct {
+	// Prefix limits the run to keys that start with it.
+	Prefix string
+	// GracePeriod keeps orphans that were stored more recently, as they may
+	// belong to an upload that is not saved yet.
+	GracePeriod time.Duration
+	// DryRun reports the orphans without deleting them.
+	DryRun bool
+}
+
+// MediaGCReport describes a garbage collection run.
+type MediaGCReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	DryRun     bool
+
+	Scanned      int
+	ScannedBytes int64
+	// Orphans are the objects past the grace period that nothing refers to.
+	Orphans     []storage.Object
+	OrphanBytes int64
+	// Recent counts the orphans still within the grace period.
+	Recent int
+
+	Deleted      int
+	DeletedBytes int64
+	// Failed are the keys of orphans that could not be deleted.
+	Failed []string
+}
+
+// MediaGCService deletes stored files that the database no longer refers to,
+// left behind by failed uploads and deleted photos and listings.
+type MediaGCService struct {
+	store media.MediaStoreI
+	blobs storage.BlobStoreI
+}
+
+func NewMediaGCService(store media.MediaStoreI, blobs storage.BlobStoreI) *MediaGCService {
+	return &MediaGCService{store: store, blobs: blobs}
+}
+
+// Collect finds the orphaned objects and, unless it is a dry run, deletes
+// them. The objects are listed before the referenced keys are loaded, so a
+// file saved in between is never taken for an orphan.
+func (s *MediaGCService) Collect(now time.Time, options MediaGCOptions) (MediaGCReport, error) {
+	report := MediaGCReport{StartedAt: now, DryRun: options.DryRun}
+
+	objects, err := s.blobs.List(options.Prefix)
+	if err != nil {
+		return report, err
+	}
+	referenced, err := s.store.ReferencedKeys()
+	if err != nil {
+		return report, err
+	}
+
+	cutoff := now.Add(-options.GracePeriod)
+	for _, object := range objects {
+		report.Scanned++
+		report.ScannedBytes += object.Size
+		if referenced[object.Key] {
+			continue
+		}
+		if object.ModTime.After(cutoff) {
+			report.Recent++
+			continue
+		}
+		report.Orphans = append(report.Orphans, object)
+		report.OrphanBytes += object.Size
+	}
+
+	if !options.DryRun {
+		for _, object := range report.Orphans {
+			if err := s.blobs.Delete(object.Key); err != nil {
+				log.Error().Err(err).Str("key", object.Key).Msg("cant delete orphaned file")
+				report.Failed = append(report.Failed, object.Key)
+				continue
+			}
+			report.Deleted++
+			report.DeletedBytes += object.Size
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaGCService_Collect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMediaStore := mocks.NewMockMediaStoreI(ctrl)
+
+	// Objects stored now are a day old for a run a day from now.
+	blobs := storage.NewMemoryStore("memory://")
+	for _, key := range []string{"luna_full.jpg", "luna_thumb.jpg", "animals/3/photo_full.jpg", "animals/3/uploads/abandoned.png"} {
+		_, err := blobs.Put(key, strings.NewReader("photo"), "image/jpeg")
+		assert.NoError(t, err)
+	}
+	later := time.Now().Add(24 * time.Hour)
+	referenced := map[string]bool{"luna_full.jpg": true, "luna_thumb.jpg": true}
+	gc := services.NewMediaGCService(mockMediaStore, blobs)
+
+	t.Run("Dry run only reports orphans", func(t *testing.T) {
+		mockMediaStore.EXPECT().ReferencedKeys().Return(referenced, nil)
+
+		report, err := gc.Collect(later, services.MediaGCOptions{GracePeriod: time.Hour, DryRun: true})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, report.Scanned)
+		assert.Equal(t, int64(20), report.ScannedBytes)
+		assert.Len(t, report.Orphans, 2)
+		assert.Equal(t, int64(10), report.OrphanBytes)
+		assert.Zero(t, report.Deleted)
+		objects, _ := blobs.List("")
+		assert.Len(t, objects, 4)
+	})
+
+	t.Run("Orphans within the grace period are kept", func(t *testing.T) {
+		mockMediaStore.EXPECT().ReferencedKeys().Return(referenced, nil)
+
+		report, err := gc.Collect(later, services.MediaGCOptions{GracePeriod: 48 * time.Hour})
+		assert.NoError(t, err)
+		assert.Empty(t, report.Orphans)
+		assert.Equal(t, 2, report.Recent)
+		objects, _ := blobs.List("")
+		assert.Len(t, objects, 4)
+	})
+
+	t.Run("Orphans under the prefix are deleted", func(t *testing.T) {
+		mockMediaStore.EXPECT().ReferencedKeys().Return(referenced, nil)
+
+		report, err := gc.Collect(later, services.MediaGCOptions{Prefix: "animals/3/uploads/", GracePeriod: time.Hour})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Scanned)
+		assert.Equal(t, 1, report.Deleted)
+		assert.Equal(t, int64(5), report.DeletedBytes)
+		_, err = blobs.Head("animals/3/uploads/abandoned.png")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = blobs.Head("animals/3/photo_full.jpg")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Nothing is deleted without the referenced keys", func(t *testing.T) {
+		mockMediaStore.EXPECT().ReferencedKeys().Return(nil, errors.New("db is down"))
+
+		_, err := gc.Collect(later, services.MediaGCOptions{GracePeriod: time.Hour})
+		assert.Error(t, err)
+		objects, _ := blobs.List("")
+		assert.Len(t, objects, 3)
+	})
+}
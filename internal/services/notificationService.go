@@ -0,0 +1,97 @@
+package services
+
+import (
+	"strconv"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/notifications"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// NotificationProducerI is the API other services use to put notifications
+// in users' in-app inboxes.
+type NotificationProducerI interface {
+	Notify(notification models.Notification) error
+	NotifyUsers(userIDs []uuid.UUID, notification models.Notification) error
+}
+
+type NotificationServiceI interface {
+	GetNotifications(userID uuid.UUID, c *gin.Context) ([]models.Notification, int64, error)
+	MarkAsRead(userID uuid.UUID, id string) error
+	MarkAllAsRead(userID uuid.UUID) error
+}
+
+type NotificationService struct {
+	store notifications.NotificationStoreI
+	// events may be nil, in which case notifications are not streamed.
+	events events.PublisherI
+}
+
+func NewNotificationService(store notifications.NotificationStoreI, publisher events.PublisherI) *NotificationService {
+	return &NotificationService{store: store, events: publisher}
+}
+
+// Notify stores the notification in the inbox of its user.
+func (s *NotificationService) Notify(notification models.Notification) error {
+	return s.create([]models.Notification{notification})
+}
+
+// NotifyUsers stores a copy of the notification in the inbox of every user.
+func (s *NotificationService) NotifyUsers(userIDs []uuid.UUID, notification models.Notification) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	batch := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		n := notification
+		n.UserID = userID
+		batch = append(batch, n)
+	}
+	return s.create(batch)
+}
+
+// create stores the notifications and streams each one to its user.
+func (s *NotificationService) create(batch []models.Notification) error {
+	if err := s.store.CreateNotifications(batch); err != nil {
+		return err
+	}
+
+	if s.events == nil {
+		return nil
+	}
+	for _, n := range batch {
+		if err := s.events.Publish(events.TopicNotification, []uuid.UUID{n.UserID}, models.ToNotificationJSON(n)); err != nil {
+			log.Error().Err(err).Uint("notificationID", n.ID).Msg("cant publish notification")
+		}
+	}
+	return nil
+}
+
+// GetNotifications returns a page of the user's inbox and their unread count.
+func (s *NotificationService) GetNotifications(userID uuid.UUID, c *gin.Context) ([]models.Notification, int64, error) {
+	page, err := s.store.GetNotifications(userID, c)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.store.CountUnread(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return page, unread, nil
+}
+
+func (s *NotificationService) MarkAsRead(userID uuid.UUID, id string) error {
+	notificationID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	return s.store.MarkAsRead(userID, uint(notificationID))
+}
+
+func (s *NotificationService) MarkAllAsRead(userID uuid.UUID) error {
+	return s.store.MarkAllAsRead(userID)
+}
@@ -0,0 +1,38 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_NotifyUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockNotificationStoreI(ctrl)
+	service := services.NewNotificationService(mockStore, nil)
+
+	t.Run("Every user gets a copy", func(t *testing.T) {
+		users := []uuid.UUID{uuid.New(), uuid.New()}
+		mockStore.EXPECT().CreateNotifications(gomock.Any()).DoAndReturn(func(batch []models.Notification) error {
+			assert.Len(t, batch, 2)
+			for i, n := range batch {
+				assert.Equal(t, users[i], n.UserID)
+				assert.Equal(t, "Luna was adopted", n.Title)
+			}
+			return nil
+		})
+
+		err := service.NotifyUsers(users, models.Notification{Type: models.NotificationTypeAnimalStatus, Title: "Luna was adopted"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("No users", func(t *testing.T) {
+		assert.NoError(t, service.NotifyUsers(nil, models.Notification{Title: "Nobody"}))
+	})
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/users"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+// NotifierI delivers a notification to its user over a single channel.
+type NotifierI interface {
+	Notify(notification models.Notification) error
+}
+
+// EmailNotifier queues notifications in the outbox as emails to the address
+// the user signed up with, in the user's locale.
+type EmailNotifier struct {
+	userStore users.UserStoreI
+	outbox    outbox.OutboxStoreI
+	emails    *mail.Renderer
+}
+
+func NewEmailNotifier(userStore users.UserStoreI, outboxStore outbox.OutboxStoreI, emails *mail.Renderer) *EmailNotifier {
+	return &EmailNotifier{userStore: userStore, outbox: outboxStore, emails: emails}
+}
+
+func (n *EmailNotifier) Notify(notification models.Notification) error {
+	user, err := n.userStore.GetByID(notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	email, err := n.emails.Render(mail.TemplateNotification, user.Locale, notification)
+	if err != nil {
+		return err
+	}
+	email.To = user.Email
+	return n.outbox.Enqueue(outbox.NewEmail(mail.TemplateNotification, email))
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/rs/zerolog/log"
+)
+
+// photoBatch stores photos concurrently, with at most a fixed number of
+// uploads at a time, and remembers what was stored so that a failed change
+// can delete it again.
+type photoBatch struct {
+	photoService photos.PhotoServiceI
+	workers      chan struct{}
+	wg           sync.WaitGroup
+
+	mu     sync.Mutex
+	photos []models.Photo
+	stored []bool
+	err    error
+}
+
+func newPhotoBatch(photoService photos.PhotoServiceI, workers int) *photoBatch {
+	return &photoBatch{photoService: photoService, workers: make(chan struct{}, workers)}
+}
+
+// Go runs the upload once a worker is free. The photo takes the next slot
+// of the result, in the order the uploads were started. Once an upload has
+// failed no more are started and Go returns false.
+func (b *photoBatch) Go(upload func() (models.Photo, error)) bool {
+	b.workers <- struct{}{}
+
+	b.mu.Lock()
+	if b.err != nil {
+		b.mu.Unlock()
+		<-b.workers
+		return false
+	}
+	i := len(b.photos)
+	b.photos = append(b.photos, models.Photo{})
+	b.stored = append(b.stored, false)
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer func() { <-b.workers }()
+
+		photo, err := upload()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if err != nil {
+			if b.err == nil {
+				b.err = err
+			}
+			return
+		}
+		b.photos[i], b.stored[i] = photo, true
+	}()
+	return true
+}
+
+// Wait returns the stored photos once every upload is done, or the first
+// error any of them failed with.
+func (b *photoBatch) Wait() ([]models.Photo, error) {
+	b.wg.Wait()
+	return b.photos, b.err
+}
+
+// Rollback waits for the uploads and deletes the files of every photo that
+// was stored. Files that can not be deleted are only logged.
+func (b *photoBatch) Rollback() {
+	b.wg.Wait()
+	for i, photo := range b.photos {
+		if !b.stored[i] {
+			continue
+		}
+		for _, key := range photo.Keys() {
+			if err := b.photoService.DeletePhoto(key); err != nil {
+				log.Error().Err(err).Str("key", key).Msg("cant roll back stored photo")
+			}
+		}
+	}
+}
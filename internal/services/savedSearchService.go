@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/animals"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/searches"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrUnsupportedFilter = errors.New("unsupported filter")
+	ErrInvalidFilter     = errors.New("invalid filter")
+)
+
+// savedSearchFilters are the listing query parameters a saved search may
+// store, with a check that the listing query can use the value.
+var savedSearchFilters = map[string]func(value string) error{
+	constants.MinAgeParam:     checkAge,
+	constants.MaxAgeParam:     checkAge,
+	constants.GenderParam:     checkGenders,
+	constants.LocationParam:   func(string) error { return nil },
+	constants.VaccinatedParam: checkBool,
+	constants.SterilizedParam: checkBool,
+	constants.SearchParam:     func(string) error { return nil },
+}
+
+func checkAge(value string) error {
+	age, err := strconv.ParseFloat(value, 32)
+	if err != nil || age < 0 {
+		return errors.New("must be a number of years")
+	}
+	return nil
+}
+
+// checkGenders accepts a JSON array, as the listing query reads it.
+func checkGenders(value string) error {
+	var genders []string
+	if err := json.Unmarshal([]byte(value), &genders); err != nil {
+		return errors.New(`must be a JSON array such as ["MALE"]`)
+	}
+	return nil
+}
+
+func checkBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return errors.New("must be true or false")
+	}
+	return nil
+}
+
+type SavedSearchServiceI interface {
+	CreateSavedSearch(userID uuid.UUID, search *models.SavedSearchJSON) (models.SavedSearch, error)
+	GetSavedSearches(userID uuid.UUID) ([]models.SavedSearch, error)
+	DeleteSavedSearch(userID uuid.UUID, id string) error
+	Unsubscribe(token string) error
+}
+
+type SavedSearchService struct {
+	store       searches.SavedSearchStoreI
+	animalStore animals.AnimalStoreI
+	inbox       NotifierI
+	email       NotifierI
+	baseURL     string
+	interval    time.Duration
+}
+
+// NewSavedSearchService creates the service. The email notifier may be nil
+// when email is not configured; digests are then only sent to the inbox.
+func NewSavedSearchService(store searches.SavedSearchStoreI, animalStore animals.AnimalStoreI, inbox, email NotifierI, baseURL string, interval time.Duration) *SavedSearchService {
+	return &SavedSearchService{
+		store:       store,
+		animalStore: animalStore,
+		inbox:       inbox,
+		email:       email,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		interval:    interval,
+	}
+}
+
+// CreateSavedSearch saves the search. Its first digest covers the animals
+// created after it was saved.
+func (s *SavedSearchService) CreateSavedSearch(userID uuid.UUID, search *models.SavedSearchJSON) (models.SavedSearch, error) {
+	for key, value := range search.Filters {
+		check, ok := savedSearchFilters[key]
+		if !ok {
+			return models.SavedSearch{}, fmt.Errorf("%w %q", ErrUnsupportedFilter, key)
+		}
+		// Empty filters are not stored.
+		if value == "" {
+			continue
+		}
+		if err := check(value); err != nil {
+			return models.SavedSearch{}, fmt.Errorf("%w %s: %v", ErrInvalidFilter, key, err)
+		}
+	}
+	savedSearch := models.FromSavedSearchJSON(*search)
+
+	token, err := newUnsubscribeToken()
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+	savedSearch.UserID = userID
+	savedSearch.UnsubscribeToken = token
+	savedSearch.LastRunAt = time.Now()
+
+	if err := s.store.CreateSavedSearch(&savedSearch); err != nil {
+		return models.SavedSearch{}, err
+	}
+	return savedSearch, nil
+}
+
+func (s *SavedSearchService) GetSavedSearches(userID uuid.UUID) ([]models.SavedSearch, error) {
+	return s.store.GetSavedSearches(userID)
+}
+
+func (s *SavedSearchService) DeleteSavedSearch(userID uuid.UUID, id string) error {
+	searchID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	return s.store.DeleteSavedSearch(userID, uint(searchID))
+}
+
+func (s *SavedSearchService) Unsubscribe(token string) error {
+	return s.store.Unsubscribe(token)
+}
+
+// Start runs the due saved searches on every tick until the context is done.
+func (s *SavedSearchService) Start(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunDue(time.Now()); err != nil {
+			log.Error().Err(err).Msg("saved search job failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs every saved search whose interval has elapsed against the
+// animals created since its last run and sends a digest of the new matches.
+func (s *SavedSearchService) RunDue(now time.Time) error {
+	var after *models.SavedSearch
+	for {
+		due, err := s.store.GetDueSavedSearches(now.Add(-s.interval), after, constants.SavedSearchBatchSize)
+		if err != nil {
+			return err
+		}
+
+		// A failing search must not hold up the others, it is retried on
+		// the next tick.
+		for i := range due {
+			if err := s.run(&due[i], now); err != nil {
+				log.Error().Err(err).Uint("savedSearchID", due[i].ID).Msg("cant run saved search")
+			}
+		}
+
+		if len(due) < constants.SavedSearchBatchSize {
+			return nil
+		}
+		last := due[len(due)-1]
+		after = &last
+	}
+}
+
+func (s *SavedSearchService) run(search *models.SavedSearch, now time.Time) error {
+	since := search.LastRunAt
+	claimed, err := s.store.ClaimRun(search, now)
+	if err != nil || !claimed {
+		return err
+	}
+
+	matches, err := s.animalStore.GetNewMatches(search.Values(), since, now, constants.SavedSearchMatchLimit)
+	if err != nil {
+		if releaseErr := s.store.ReleaseRun(search, now); releaseErr != nil {
+			log.Error().Err(releaseErr).Uint("savedSearchID", search.ID).Msg("cant release saved search run")
+		}
+		return err
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	notification := s.digest(search, matches)
+	if search.NotifyInApp {
+		if err := s.inbox.Notify(notification); err != nil {
+			log.Error().Err(err).Uint("savedSearchID", search.ID).Msg("cant deliver saved search digest to inbox")
+		}
+	}
+	if search.NotifyEmail && s.email != nil {
+		notification.Body += "\n\nUnsubscribe from these emails: " + s.baseURL + "/searches/unsubscribe/" + search.UnsubscribeToken
+		if err := s.email.Notify(notification); err != nil {
+			log.Error().Err(err).Uint("savedSearchID", search.ID).Msg("cant email saved search digest")
+		}
+	}
+	return nil
+}
+
+func (s *SavedSearchService) digest(search *models.SavedSearch, matches []models.Animal) models.Notification {
+	var body strings.Builder
+	for i, animal := range matches {
+		if i == constants.SavedSearchDigestSize {
+			fmt.Fprintf(&body, "and %d more\n", len(matches)-i)
+			break
+		}
+		fmt.Fprintf(&body, "- %s (%s, %s)\n", animal.Name, animal.Type, strconv.FormatFloat(float64(animal.Age), 'g', -1, 32))
+	}
+
+	link := s.baseURL + "/animal/all"
+	if search.Query != "" {
+		link += "?" + search.Query
+	}
+
+	return models.Notification{
+		UserID: search.UserID,
+		Type:   models.NotificationTypeSavedSearch,
+		Title:  fmt.Sprintf("New matches for %q", search.Name),
+		Body:   strings.TrimSuffix(body.String(), "\n"),
+		Link:   link,
+	}
+}
+
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchService_CreateSavedSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockSavedSearchStoreI(ctrl)
+	service := services.NewSavedSearchService(mockStore, mocks.NewMockAnimalStoreI(ctrl), nil, nil, "", time.Hour)
+	userID := uuid.New()
+
+	t.Run("Successful CreateSavedSearch", func(t *testing.T) {
+		mockStore.EXPECT().CreateSavedSearch(gomock.Any()).Return(nil)
+
+		search, err := service.CreateSavedSearch(userID, &models.SavedSearchJSON{
+			Name:    "Young dogs",
+			Filters: map[string]string{constants.MaxAgeParam: "2", constants.SearchParam: "dog"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, search.UserID)
+		assert.Equal(t, "maxAge=2&q=dog", search.Query)
+		assert.Len(t, search.UnsubscribeToken, 64)
+	})
+
+	t.Run("Unsupported filter", func(t *testing.T) {
+		_, err := service.CreateSavedSearch(userID, &models.SavedSearchJSON{
+			Name:    "Sorted",
+			Filters: map[string]string{constants.SortParam: "name"},
+		})
+
+		assert.ErrorIs(t, err, services.ErrUnsupportedFilter)
+	})
+
+	t.Run("Invalid filter values", func(t *testing.T) {
+		for key, value := range map[string]string{
+			constants.MinAgeParam:     "abc",
+			constants.MaxAgeParam:     "-1",
+			constants.GenderParam:     "MALE",
+			constants.VaccinatedParam: "yes",
+		} {
+			_, err := service.CreateSavedSearch(userID, &models.SavedSearchJSON{
+				Name:    "Invalid",
+				Filters: map[string]string{key: value},
+			})
+
+			assert.ErrorIs(t, err, services.ErrInvalidFilter, key)
+		}
+	})
+}
+
+func TestSavedSearchService_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockSavedSearchStoreI(ctrl)
+	mockAnimalStore := mocks.NewMockAnimalStoreI(ctrl)
+	mockInbox := mocks.NewMockNotifierI(ctrl)
+	mockEmail := mocks.NewMockNotifierI(ctrl)
+	service := services.NewSavedSearchService(mockStore, mockAnimalStore, mockInbox, mockEmail, "https://findyourpet.test/", 24*time.Hour)
+
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	lastRun := now.Add(-25 * time.Hour)
+	search := models.SavedSearch{
+		UserID:           uuid.New(),
+		Name:             "Cats",
+		Query:            "q=cat",
+		NotifyInApp:      true,
+		NotifyEmail:      true,
+		UnsubscribeToken: "token",
+		LastRunAt:        lastRun,
+	}
+	search.ID = 4
+
+	t.Run("Digest is sent to inbox and email", func(t *testing.T) {
+		mockStore.EXPECT().GetDueSavedSearches(now.Add(-24*time.Hour), nil, constants.SavedSearchBatchSize).
+			Return([]models.SavedSearch{search}, nil)
+		mockStore.EXPECT().ClaimRun(gomock.Any(), now).Return(true, nil)
+		mockAnimalStore.EXPECT().GetNewMatches(search.Values(), lastRun, now, constants.SavedSearchMatchLimit).
+			Return([]models.Animal{{Name: "Tom", Type: "cat", Age: 2}}, nil)
+
+		mockInbox.EXPECT().Notify(gomock.Any()).DoAndReturn(func(n models.Notification) error {
+			assert.Equal(t, search.UserID, n.UserID)
+			assert.Equal(t, models.NotificationTypeSavedSearch, n.Type)
+			assert.Equal(t, "- Tom (cat, 2)", n.Body)
+			assert.Equal(t, "https://findyourpet.test/animal/all?q=cat", n.Link)
+			return nil
+		})
+		mockEmail.EXPECT().Notify(gomock.Any()).DoAndReturn(func(n models.Notification) error {
+			assert.True(t, strings.HasSuffix(n.Body, "https://findyourpet.test/searches/unsubscribe/token"))
+			return nil
+		})
+
+		assert.NoError(t, service.RunDue(now))
+	})
+
+	t.Run("Search claimed by another run is skipped", func(t *testing.T) {
+		mockStore.EXPECT().GetDueSavedSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.SavedSearch{search}, nil)
+		mockStore.EXPECT().ClaimRun(gomock.Any(), now).Return(false, nil)
+
+		assert.NoError(t, service.RunDue(now))
+	})
+
+	t.Run("Delivery failure does not stop the job", func(t *testing.T) {
+		inAppOnly := search
+		inAppOnly.NotifyEmail = false
+		mockStore.EXPECT().GetDueSavedSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.SavedSearch{inAppOnly}, nil)
+		mockStore.EXPECT().ClaimRun(gomock.Any(), now).Return(true, nil)
+		mockAnimalStore.EXPECT().GetNewMatches(gomock.Any(), lastRun, now, gomock.Any()).
+			Return([]models.Animal{{Name: "Tom"}}, nil)
+		mockInbox.EXPECT().Notify(gomock.Any()).Return(errors.New("db is down"))
+
+		assert.NoError(t, service.RunDue(now))
+	})
+
+	t.Run("Failed search releases its run and the others still run", func(t *testing.T) {
+		other := search
+		other.ID = 5
+		other.NotifyEmail = false
+		mockStore.EXPECT().GetDueSavedSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.SavedSearch{search, other}, nil)
+		mockStore.EXPECT().ClaimRun(gomock.Any(), now).Return(true, nil).Times(2)
+		gomock.InOrder(
+			mockAnimalStore.EXPECT().GetNewMatches(gomock.Any(), lastRun, now, gomock.Any()).Return(nil, errors.New("statement timeout")),
+			mockAnimalStore.EXPECT().GetNewMatches(gomock.Any(), lastRun, now, gomock.Any()).Return([]models.Animal{{Name: "Tom"}}, nil),
+		)
+		mockStore.EXPECT().ReleaseRun(gomock.Any(), now).DoAndReturn(func(s *models.SavedSearch, claimedAt time.Time) error {
+			assert.Equal(t, uint(4), s.ID)
+			assert.Equal(t, lastRun, s.LastRunAt, "the next run covers the same window")
+			return nil
+		})
+		mockInbox.EXPECT().Notify(gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.RunDue(now))
+	})
+
+	t.Run("Next batch continues after the previous one", func(t *testing.T) {
+		batch := make([]models.SavedSearch, constants.SavedSearchBatchSize)
+		for i := range batch {
+			batch[i] = search
+			batch[i].ID = uint(i + 1)
+		}
+		mockStore.EXPECT().GetDueSavedSearches(gomock.Any(), nil, gomock.Any()).Return(batch, nil)
+		mockStore.EXPECT().ClaimRun(gomock.Any(), now).Return(false, nil).Times(len(batch))
+		mockStore.EXPECT().GetDueSavedSearches(gomock.Any(), &batch[len(batch)-1], gomock.Any()).Return(nil, nil)
+
+		assert.NoError(t, service.RunDue(now))
+	})
+}
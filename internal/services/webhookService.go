@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/webhooks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/retry"
+	webhook "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/webhooks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrUnknownEvent = errors.New("unknown webhook event")
+
+// WebhookProducerI is the API other services use to send events about a
+// user's animals to the webhooks of that user.
+type WebhookProducerI interface {
+	Enqueue(ownerID uuid.UUID, eventType string, data interface{}) error
+}
+
+type WebhookServiceI interface {
+	CreateWebhook(userID uuid.UUID, webhook *models.WebhookJSON) (models.Webhook, error)
+	GetWebhooks(userID uuid.UUID) ([]models.Webhook, error)
+	DeleteWebhook(userID uuid.UUID, id string) error
+	GetDeliveries(userID uuid.UUID, id string, c *gin.Context) ([]models.WebhookDelivery, error)
+	Ping(userID uuid.UUID, id string) (models.WebhookDelivery, error)
+}
+
+type WebhookService struct {
+	store  webhooks.WebhookStoreI
+	client *http.Client
+}
+
+func NewWebhookService(store webhooks.WebhookStoreI, client *http.Client) *WebhookService {
+	return &WebhookService{store: store, client: client}
+}
+
+// webhookEvent is the JSON body posted to webhooks.
+type webhookEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+func (s *WebhookService) CreateWebhook(userID uuid.UUID, body *models.WebhookJSON) (models.Webhook, error) {
+	// Only events that something sends can be subscribed to.
+	for _, event := range body.Events {
+		if !webhook.IsEventType(event) {
+			return models.Webhook{}, fmt.Errorf("%w: %s", ErrUnknownEvent, event)
+		}
+	}
+	if err := netguard.CheckPublicURL(body.URL); err != nil {
+		return models.Webhook{}, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return models.Webhook{}, err
+	}
+
+	w := models.FromWebhookJSON(*body)
+	w.UserID = userID
+	w.Secret = hex.EncodeToString(secret)
+	if err := s.store.CreateWebhook(&w); err != nil {
+		return models.Webhook{}, err
+	}
+	return w, nil
+}
+
+func (s *WebhookService) GetWebhooks(userID uuid.UUID) ([]models.Webhook, error) {
+	return s.store.GetWebhooks(userID)
+}
+
+func (s *WebhookService) DeleteWebhook(userID uuid.UUID, id string) error {
+	webhookID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	return s.store.DeleteWebhook(userID, uint(webhookID))
+}
+
+func (s *WebhookService) GetDeliveries(userID uuid.UUID, id string, c *gin.Context) ([]models.WebhookDelivery, error) {
+	w, err := s.webhookOf(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetDeliveries(w.ID, c)
+}
+
+// Ping sends a ping event to the webhook right away. A failed ping is retried
+// like any other delivery.
+func (s *WebhookService) Ping(userID uuid.UUID, id string) (models.WebhookDelivery, error) {
+	w, err := s.webhookOf(userID, id)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	now := time.Now()
+	delivery, err := newDelivery(w, webhook.EventPing, gin.H{"webhookId": w.ID}, now)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	deliveries := []models.WebhookDelivery{delivery}
+	if err := s.store.CreateDeliveries(deliveries); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	delivery = deliveries[0]
+	delivery.Webhook = &w
+	s.attempt(&delivery, now)
+	if err := s.store.UpdateDelivery(&delivery); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// Enqueue queues the event for every webhook of the owner subscribed to it.
+func (s *WebhookService) Enqueue(ownerID uuid.UUID, eventType string, data interface{}) error {
+	subscribed, err := s.store.GetSubscribedWebhooks(ownerID, eventType)
+	if err != nil || len(subscribed) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(subscribed))
+	for _, w := range subscribed {
+		delivery, err := newDelivery(w, eventType, data, now)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return s.store.CreateDeliveries(deliveries)
+}
+
+func newDelivery(w models.Webhook, eventType string, data interface{}, now time.Time) (models.WebhookDelivery, error) {
+	payload, err := json.Marshal(webhookEvent{ID: uuid.NewString(), Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	return models.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// Start sends the due deliveries on every tick until the context is done.
+func (s *WebhookService) Start(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if err := s.DispatchDue(time.Now()); err != nil {
+			log.Error().Err(err).Msg("webhook delivery failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends the pending deliveries that are due, scheduling a retry
+// with exponential backoff for the ones that fail.
+func (s *WebhookService) DispatchDue(now time.Time) error {
+	for {
+		deliveries, err := s.store.ClaimDueDeliveries(now, constants.WebhookBatchSize, constants.WebhookLease)
+		if err != nil {
+			return err
+		}
+
+		for i := range deliveries {
+			s.attempt(&deliveries[i], now)
+			if err := s.store.UpdateDelivery(&deliveries[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < constants.WebhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// attempt sends the delivery once and records the outcome on it.
+func (s *WebhookService) attempt(delivery *models.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+
+	if delivery.Webhook == nil || !delivery.Webhook.Active {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = "webhook was deleted or disabled"
+		return
+	}
+
+	code, err := s.send(delivery.Webhook, delivery, now)
+	delivery.LastStatusCode = code
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= constants.WebhookMaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(retry.Backoff(delivery.Attempts, constants.WebhookRetryBase, constants.WebhookRetryMax))
+}
+
+// send posts the signed payload, returning the response status code.
+func (s *WebhookService) send(w *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.WebhookTimeout)
+	defer cancel()
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, delivery.EventType)
+	req.Header.Set(webhook.DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(w.Secret, now, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) webhookOf(userID uuid.UUID, id string) (models.Webhook, error) {
+	webhookID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return s.store.GetWebhook(userID, uint(webhookID))
+}
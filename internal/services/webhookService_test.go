@@ -0,0 +1,160 @@
+package services_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/mocks"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/webhooks"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockWebhookStoreI(ctrl)
+	service := services.NewWebhookService(mockStore, http.DefaultClient)
+	userID := uuid.New()
+
+	t.Run("Public address", func(t *testing.T) {
+		mockStore.EXPECT().CreateWebhook(gomock.Any()).Return(nil)
+
+		w, err := service.CreateWebhook(userID, &models.WebhookJSON{URL: "https://93.184.216.34/hooks", Events: []string{webhooks.EventAnimalLiked}})
+		assert.NoError(t, err)
+		assert.Equal(t, userID, w.UserID)
+		assert.Len(t, w.Secret, 64)
+	})
+
+	t.Run("Internal address", func(t *testing.T) {
+		_, err := service.CreateWebhook(userID, &models.WebhookJSON{URL: "http://10.0.0.5:8080/hooks", Events: []string{webhooks.EventAnimalLiked}})
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+	})
+
+	t.Run("Reserved event", func(t *testing.T) {
+		_, err := service.CreateWebhook(userID, &models.WebhookJSON{URL: "https://93.184.216.34/hooks", Events: []string{webhooks.EventApplicationSubmitted}})
+		assert.ErrorIs(t, err, services.ErrUnknownEvent)
+	})
+}
+
+func TestWebhookService_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockWebhookStoreI(ctrl)
+	service := services.NewWebhookService(mockStore, http.DefaultClient)
+	ownerID := uuid.New()
+
+	t.Run("A delivery per subscribed webhook", func(t *testing.T) {
+		subscribed := []models.Webhook{{URL: "http://a.example"}, {URL: "http://b.example"}}
+		subscribed[0].ID, subscribed[1].ID = 1, 2
+		mockStore.EXPECT().GetSubscribedWebhooks(ownerID, webhooks.EventAnimalLiked).Return(subscribed, nil)
+		mockStore.EXPECT().CreateDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+			assert.Len(t, deliveries, 2)
+			for i, d := range deliveries {
+				assert.Equal(t, subscribed[i].ID, d.WebhookID)
+				assert.Equal(t, models.WebhookDeliveryPending, d.Status)
+				assert.Contains(t, d.Payload, `"type":"animal.liked"`)
+			}
+			return nil
+		})
+
+		assert.NoError(t, service.Enqueue(ownerID, webhooks.EventAnimalLiked, map[string]uint{"animalId": 7}))
+	})
+
+	t.Run("No subscribers", func(t *testing.T) {
+		mockStore.EXPECT().GetSubscribedWebhooks(ownerID, webhooks.EventAnimalCreated).Return(nil, nil)
+
+		assert.NoError(t, service.Enqueue(ownerID, webhooks.EventAnimalCreated, nil))
+	})
+}
+
+func TestWebhookService_DispatchDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mocks.NewMockWebhookStoreI(ctrl)
+	service := services.NewWebhookService(mockStore, http.DefaultClient)
+	now := time.Now()
+
+	t.Run("Signed delivery succeeds", func(t *testing.T) {
+		payload := `{"type":"animal.created"}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, payload, string(body))
+			assert.Equal(t, webhooks.Sign("secret", now, body), r.Header.Get(webhooks.SignatureHeader))
+			assert.Equal(t, webhooks.EventAnimalCreated, r.Header.Get(webhooks.EventHeader))
+			assert.Equal(t, "5", r.Header.Get(webhooks.DeliveryHeader))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook := &models.Webhook{URL: server.URL, Secret: "secret", Active: true}
+		delivery := models.WebhookDelivery{ID: 5, EventType: webhooks.EventAnimalCreated, Payload: payload, Status: models.WebhookDeliveryPending, Webhook: webhook}
+		mockStore.EXPECT().ClaimDueDeliveries(now, constants.WebhookBatchSize, constants.WebhookLease).Return([]models.WebhookDelivery{delivery}, nil)
+		mockStore.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d *models.WebhookDelivery) error {
+			assert.Equal(t, models.WebhookDeliverySucceeded, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			assert.Equal(t, http.StatusNoContent, d.LastStatusCode)
+			assert.NotNil(t, d.DeliveredAt)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Failed delivery is retried with backoff", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook := &models.Webhook{URL: server.URL, Secret: "secret", Active: true}
+		delivery := models.WebhookDelivery{ID: 6, Payload: "{}", Status: models.WebhookDeliveryPending, Attempts: 2, Webhook: webhook}
+		mockStore.EXPECT().ClaimDueDeliveries(now, constants.WebhookBatchSize, constants.WebhookLease).Return([]models.WebhookDelivery{delivery}, nil)
+		mockStore.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d *models.WebhookDelivery) error {
+			assert.Equal(t, models.WebhookDeliveryPending, d.Status)
+			assert.Equal(t, 3, d.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, d.LastStatusCode)
+			assert.Equal(t, now.Add(4*constants.WebhookRetryBase), d.NextAttemptAt)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Delivery fails after the last attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		webhook := &models.Webhook{URL: server.URL, Secret: "secret", Active: true}
+		delivery := models.WebhookDelivery{ID: 7, Payload: "{}", Status: models.WebhookDeliveryPending, Attempts: constants.WebhookMaxAttempts - 1, Webhook: webhook}
+		mockStore.EXPECT().ClaimDueDeliveries(now, constants.WebhookBatchSize, constants.WebhookLease).Return([]models.WebhookDelivery{delivery}, nil)
+		mockStore.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d *models.WebhookDelivery) error {
+			assert.Equal(t, models.WebhookDeliveryFailed, d.Status)
+			assert.Equal(t, constants.WebhookMaxAttempts, d.Attempts)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+
+	t.Run("Deleted webhook", func(t *testing.T) {
+		delivery := models.WebhookDelivery{ID: 8, Payload: "{}", Status: models.WebhookDeliveryPending}
+		mockStore.EXPECT().ClaimDueDeliveries(now, constants.WebhookBatchSize, constants.WebhookLease).Return([]models.WebhookDelivery{delivery}, nil)
+		mockStore.EXPECT().UpdateDelivery(gomock.Any()).DoAndReturn(func(d *models.WebhookDelivery) error {
+			assert.Equal(t, models.WebhookDeliveryFailed, d.Status)
+			return nil
+		})
+
+		assert.NoError(t, service.DispatchDue(now))
+	})
+}
@@ -2,45 +2,162 @@ package animals
 
 import (
 	"encoding/json"
+	"errors"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/feed"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/sorting"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AnimalStoreI interface {
 	AddAnimal(animal *models.Animal) error
 	AddAnimals(animals []*models.Animal) error
+	AddPhotos(photos []models.Photo) error
+	DeletePhoto(photo models.Photo) error
+	UpdatePhotoPositions(photos []models.Photo) error
+	SaveImage(image *models.Image) error
+	FindSimilarAnimals(hashes []int64, animalID uint, maxDistance int) ([]models.SimilarAnimal, error)
+	AddModerationItems(items []models.ModerationItem) error
+	GetModerationItems(status string, afterID uint, limit int) ([]models.ModerationItem, error)
+	ResolveModerationItem(id uint, status string, moderatorID uuid.UUID) error
+	GetAnimalsAfter(afterID uint, limit int) ([]models.Animal, error)
 	GetAllAnimals(c *gin.Context) ([]models.Animal, error)
 	GetById(id string) (models.Animal, error)
 	GetLikedAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error)
 	GetNotSeenAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error)
-	MarkAsSeen(animalID uint, userID uuid.UUID, animalLiked bool) error
+	GetFeedCandidates(userID uuid.UUID, c *gin.Context, limit int) ([]models.Animal, error)
+	GetSwipeSignals(userID uuid.UUID, limit int) ([]ranking.Signal, error)
+	GetNewMatches(query url.Values, since, until time.Time, limit int) ([]models.Animal, error)
+	GetFavoritedBy(animalID uint) ([]uuid.UUID, error)
+	MarkAsSeen(animalID uint, userID uuid.UUID, animalLiked bool, client string) (bool, error)
+	UpdateAnimal(animal *models.Animal) error
 }
 
 type AnimalStore struct {
-	db *gorm.DB
+	db           *gorm.DB
+	reshowPolicy feed.ReshowPolicy
 }
 
-func NewAnimalStore(db *gorm.DB) *AnimalStore {
-	return &AnimalStore{db: db}
+func NewAnimalStore(db *gorm.DB, reshowPolicy feed.ReshowPolicy) *AnimalStore {
+	return &AnimalStore{db: db, reshowPolicy: reshowPolicy}
 }
 
+// AddAnimal inserts the animal together with its image and photos.
 func (s *AnimalStore) AddAnimal(animal *models.Animal) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(animal).Error
+	})
+}
+
+// FindSimilarAnimals returns the other listings with an image or photo whose
+// perceptual hash is at most maxDistance bits from one of the hashes,
+// closest first.
+func (s *AnimalStore) FindSimilarAnimals(hashes []int64, animalID uint, maxDistance int) ([]models.SimilarAnimal, error) {
+	var similar []models.SimilarAnimal
+	err := s.db.Raw(`SELECT animal_id, MIN(distance) AS distance FROM (
+			SELECT i.animal_id, length(replace((i.perceptual_hash # h.hash)::bit(64)::text, '0', '')) AS distance
+			FROM (
+				SELECT animal_id, perceptual_hash FROM images WHERE deleted_at IS NULL
+				UNION ALL
+				SELECT animal_id, perceptual_hash FROM photos WHERE deleted_at IS NULL
+			) i
+			CROSS JOIN unnest(ARRAY[?]::bigint[]) AS h(hash)
+			WHERE i.perceptual_hash IS NOT NULL AND i.animal_id <> ?
+				AND i.animal_id IN (SELECT id FROM animals WHERE deleted_at IS NULL)
+		) d
+		WHERE distance <= ?
+		GROUP BY animal_id
+		ORDER BY distance, animal_id
+		LIMIT ?`, hashes, animalID, maxDistance, constants.DuplicateCandidateLimit).
+		Scan(&similar).Error
+	return similar, err
+}
+
+func (s *AnimalStore) AddModerationItems(items []models.ModerationItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return s.db.Create(&items).Error
+}
 
-	return s.db.Create(&animal).Error
+// GetModerationItems returns the items with the status after afterID, oldest
+// first.
+func (s *AnimalStore) GetModerationItems(status string, afterID uint, limit int) ([]models.ModerationItem, error) {
+	var items []models.ModerationItem
+	err := s.db.Where("status = ? AND id > ?", status, afterID).Order("id").Limit(limit).Find(&items).Error
+	return items, err
 }
+
+// ResolveModerationItem closes a pending item with the status. Items that
+// are already closed are not found.
+func (s *AnimalStore) ResolveModerationItem(id uint, status string, moderatorID uuid.UUID) error {
+	result := s.db.Model(&models.ModerationItem{}).
+		Where("id = ? AND status = ?", id, models.ModerationPending).
+		Updates(map[string]interface{}{"status": status, "resolved_by": moderatorID, "resolved_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetAnimalsAfter pages through every listing by ID, with its media.
+func (s *AnimalStore) GetAnimalsAfter(afterID uint, limit int) ([]models.Animal, error) {
+	var animals []models.Animal
+	err := s.db.Scopes(s.addMediaPreload).Where("id > ?", afterID).Order("id").Limit(limit).Find(&animals).Error
+	return animals, err
+}
+
 func (s *AnimalStore) AddAnimals(animals []*models.Animal) error {
 
 	return s.db.Create(animals).Error
 }
 
+func (s *AnimalStore) AddPhotos(photos []models.Photo) error {
+	return s.db.Create(&photos).Error
+}
+
+// DeletePhoto removes the photo for good, as its files are deleted with it.
+func (s *AnimalStore) DeletePhoto(photo models.Photo) error {
+	return s.db.Unscoped().Delete(&photo).Error
+}
+
+// UpdatePhotoPositions saves the positions of the photos together.
+func (s *AnimalStore) UpdatePhotoPositions(photos []models.Photo) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, photo := range photos {
+			if err := tx.Model(&photo).Update("position", photo.Position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveImage creates or replaces the cover image of an animal.
+func (s *AnimalStore) SaveImage(image *models.Image) error {
+	return s.db.Save(image).Error
+}
+
+// UpdateAnimal saves the listing columns of the animal, leaving media untouched.
+func (s *AnimalStore) UpdateAnimal(animal *models.Animal) error {
+	return s.db.Omit(clause.Associations).Save(animal).Error
+}
+
 func (s *AnimalStore) GetById(id string) (models.Animal, error) {
 	animal := models.Animal{}
 	result := s.db.Scopes(s.addMediaPreload).First(&animal, id)
@@ -50,31 +167,83 @@ func (s *AnimalStore) GetById(id string) (models.Animal, error) {
 func (s *AnimalStore) GetLikedAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error) {
 	var animals []models.Animal
 	err := s.db.
-		Joins("JOIN seen_animals ON animals.id = seen_animals.animal_id").
-		Where("seen_animals.user_id = ? AND seen_animals.liked = ?", userID, true).
-		Scopes(s.addMediaPreload, pagination.Paginate(c)).
+		Joins("JOIN favorites ON animals.id = favorites.animal_id").
+		Where("favorites.user_id = ?", userID).
+		Preload("Favorite", "user_id = ?", userID).
+		Scopes(s.addMediaPreload, s.selectColumns(c), s.buildSearchQuery(searchTerm(c)), s.paginate(c)).
 		Find(&animals).Error
 	if err != nil {
 		return nil, err
 	}
-	return animals, nil
+	return s.nextPage(c, animals), nil
 }
 
 func (s *AnimalStore) GetAllAnimals(c *gin.Context) ([]models.Animal, error) {
 	animals := []models.Animal{}
-	result := s.db.Scopes(s.addMediaPreload, s.buildPetQuery(c), pagination.Paginate(c)).Find(&animals)
-	return animals, result.Error
+	result := s.db.Scopes(s.addMediaPreload, s.selectColumns(c), s.buildPetQuery(c.Request.URL.Query()), s.buildSearchQuery(searchTerm(c)), s.paginate(c)).Find(&animals)
+	return s.nextPage(c, animals), result.Error
 }
 
 func (s *AnimalStore) GetNotSeenAnimals(userID uuid.UUID, c *gin.Context) ([]models.Animal, error) {
 	var animals []models.Animal
-	twentyFourHoursAgo := time.Now().Add(-24 * time.Hour)
 
 	err := s.db.
-		Select("animals.*").
-		Joins("LEFT JOIN seen_animals ON animals.id = seen_animals.animal_id AND seen_animals.user_id = ?", userID).
-		Where("seen_animals.seen_at < ? OR seen_animals.seen_at IS NULL", twentyFourHoursAgo).
-		Scopes(s.addMediaPreload, s.buildPetQuery(c), pagination.Paginate(c)).
+		Scopes(s.notSeenBy(userID), s.addMediaPreload, s.selectColumns(c), s.buildPetQuery(c.Request.URL.Query()), s.buildSearchQuery(searchTerm(c)), s.paginate(c)).
+		Find(&animals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.nextPage(c, animals), nil
+}
+
+// GetFeedCandidates returns up to limit of the newest animals the swipe feed
+// may show the user, for the feed ranking to order.
+func (s *AnimalStore) GetFeedCandidates(userID uuid.UUID, c *gin.Context, limit int) ([]models.Animal, error) {
+	var animals []models.Animal
+
+	err := s.db.
+		Scopes(s.notSeenBy(userID), s.addMediaPreload, s.selectColumns(c), s.buildPetQuery(c.Request.URL.Query()), s.buildSearchQuery(searchTerm(c))).
+		Order("animals.created_at DESC, animals.id DESC").
+		Limit(limit).
+		Find(&animals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return animals, nil
+}
+
+// GetSwipeSignals returns the attributes of up to limit animals the user most
+// recently swiped on, for learning their preferences.
+func (s *AnimalStore) GetSwipeSignals(userID uuid.UUID, limit int) ([]ranking.Signal, error) {
+	var signals []ranking.Signal
+
+	err := s.db.
+		Model(&models.SeenAnimal{}).
+		Select("animals.type AS animal_type, animals.gender, animals.age, seen_animals.liked").
+		Joins("JOIN animals ON animals.id = seen_animals.animal_id AND animals.deleted_at IS NULL").
+		Where("seen_animals.user_id = ?", userID).
+		Order("seen_animals.seen_at DESC").
+		Limit(limit).
+		Scan(&signals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return signals, nil
+}
+
+// GetNewMatches returns up to limit of the newest animals created in
+// [since, until) that match the given listing filters.
+func (s *AnimalStore) GetNewMatches(query url.Values, since, until time.Time, limit int) ([]models.Animal, error) {
+	var animals []models.Animal
+
+	err := s.db.
+		Scopes(s.addMediaPreload, s.buildPetQuery(query), s.buildSearchQuery(strings.TrimSpace(query.Get(constants.SearchParam)))).
+		Where("animals.created_at >= ? AND animals.created_at < ?", since, until).
+		Order("animals.created_at DESC, animals.id DESC").
+		Limit(limit).
 		Find(&animals).Error
 	if err != nil {
 		return nil, err
@@ -83,21 +252,74 @@ func (s *AnimalStore) GetNotSeenAnimals(userID uuid.UUID, c *gin.Context) ([]mod
 	return animals, nil
 }
 
-func (s *AnimalStore) MarkAsSeen(animalID uint, userID uuid.UUID, animalLiked bool) error {
+// GetFavoritedBy returns the IDs of the users who have the animal in their favorites.
+func (s *AnimalStore) GetFavoritedBy(animalID uint) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := s.db.Model(&models.Favorite{}).Where("animal_id = ?", animalID).Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// MarkAsSeen records the swipe on seen_animals and appends it to the swipe
+// event log together with the state needed to undo it. It reports whether a
+// like added the animal to the user's favorites.
+func (s *AnimalStore) MarkAsSeen(animalID uint, userID uuid.UUID, animalLiked bool, client string) (bool, error) {
 	seenAnimal := models.SeenAnimal{AnimalID: animalID, UserID: userID, Liked: animalLiked, SeenAt: time.Now()}
+	event := models.SwipeEvent{UserID: userID, AnimalID: animalID, Action: models.SwipeActionPass, Client: client}
+	if animalLiked {
+		event.Action = models.SwipeActionLike
+	}
 
-	return s.db.Where("user_id = ? AND animal_id = ?", userID, animalID).Save(&seenAnimal).Error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var previous models.SeenAnimal
+		err := tx.Where("user_id = ? AND animal_id = ?", userID, animalID).Take(&previous).Error
+		switch {
+		case err == nil:
+			event.PreviousLiked = &previous.Liked
+			event.PreviousSeenAt = &previous.SeenAt
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
 
+		if err := tx.Where("user_id = ? AND animal_id = ?", userID, animalID).Save(&seenAnimal).Error; err != nil {
+			return err
+		}
+
+		if animalLiked {
+			// A like adds the animal to favorites; passing on it later keeps the favorite.
+			favorite := models.Favorite{UserID: userID, AnimalID: animalID}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&favorite)
+			if result.Error != nil {
+				return result.Error
+			}
+			event.AddedFavorite = result.RowsAffected == 1
+		}
+
+		return tx.Create(&event).Error
+	})
+	return err == nil && event.AddedFavorite, err
 }
 
-func (s *AnimalStore) buildPetQuery(c *gin.Context) func(db *gorm.DB) *gorm.DB {
+// notSeenBy keeps the animals the re-show policy lets the swipe feed show the
+// user. Reserved and adopted animals are never in the feed.
+func (s *AnimalStore) notSeenBy(userID uuid.UUID) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		minAge := c.Query(constants.MinAgeParam)
-		maxAge := c.Query(constants.MaxAgeParam)
-		genders := c.Query(constants.GenderParam)
-		location := c.Query(constants.LocationParam)
-		vaccinated := c.Query(constants.VaccinatedParam)
-		sterilized := c.Query(constants.SterilizedParam)
+		return db.
+			Joins("LEFT JOIN seen_animals ON animals.id = seen_animals.animal_id AND seen_animals.user_id = ?", userID).
+			Where("animals.status = ?", models.AnimalStatusAvailable).
+			Where(s.reshowPolicy.Condition(time.Now()))
+	}
+}
+
+// buildPetQuery filters animals by the listing filter parameters, which come
+// from the request or from a saved search.
+func (s *AnimalStore) buildPetQuery(query url.Values) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		minAge := query.Get(constants.MinAgeParam)
+		maxAge := query.Get(constants.MaxAgeParam)
+		genders := query.Get(constants.GenderParam)
+		location := query.Get(constants.LocationParam)
+		vaccinated := query.Get(constants.VaccinatedParam)
+		sterilized := query.Get(constants.SterilizedParam)
 
 		if minAge != "" {
 			db = db.Where("age >= ?", minAge)
@@ -137,6 +359,183 @@ func (s *AnimalStore) buildPetQuery(c *gin.Context) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// buildSearchQuery restricts results to animals matching the full-text search query.
+func (s *AnimalStore) buildSearchQuery(q string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q == "" {
+			return db
+		}
+
+		return db.Where("animals.search_vector @@ websearch_to_tsquery(?, ?)", constants.SearchLanguage, q)
+	}
+}
+
+// selectColumns selects the animal columns together with the relevance rank,
+// highlighted snippets, distance and sort name when the request asks for them.
+func (s *AnimalStore) selectColumns(c *gin.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		columns := []string{"animals.*"}
+		var args []interface{}
+
+		if q := searchTerm(c); q != "" {
+			lang := constants.SearchLanguage
+			opts := constants.SearchHighlightOptions
+			rank, rankArgs := rankExpr(q)
+			columns = append(columns,
+				rank+" AS search_rank",
+				"ts_headline(?, "+escapeHTMLExpr("animals.name")+", websearch_to_tsquery(?, ?), ?) AS name_highlight",
+				"ts_headline(?, "+escapeHTMLExpr("animals.description")+", websearch_to_tsquery(?, ?), ?) AS description_highlight",
+			)
+			args = append(args, rankArgs...)
+			args = append(args, lang, lang, q, opts, lang, lang, q, opts)
+		}
+
+		if params, err := sorting.GetSortQueryParams(c); err == nil {
+			if params.HasLocation() {
+				distance, distanceArgs := distanceExpr(*params.Latitude, *params.Longitude)
+				columns = append(columns, distance+" AS distance")
+				args = append(args, distanceArgs...)
+			}
+			if params.Order == sorting.Name {
+				columns = append(columns, sortNameExpr+" AS sort_name")
+			}
+		}
+
+		return db.Clauses(clause.Select{Expression: clause.Expr{SQL: strings.Join(columns, ", "), Vars: args}})
+	}
+}
+
+// paginate orders the listing by the requested sort key, always breaking ties
+// on the animal ID so that pages are stable, and limits it to a single page.
+// Keyset pagination is used when the client sent a cursor.
+func (s *AnimalStore) paginate(c *gin.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		params, err := sorting.GetSortQueryParams(c)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		columns := keysetColumns(animalSortKeys(params, searchTerm(c)))
+		db = db.Clauses(pagination.OrderBy(columns))
+		if pagination.IsCursorMode(c) {
+			return pagination.PaginateKeyset(c, string(params.Order), columns)(db)
+		}
+		return pagination.Paginate(c)(db)
+	}
+}
+
+// nextPage trims a keyset page and sets the cursor for the following one.
+func (s *AnimalStore) nextPage(c *gin.Context, animals []models.Animal) []models.Animal {
+	if !pagination.IsCursorMode(c) {
+		return animals
+	}
+	params, err := sorting.GetSortQueryParams(c)
+	if err != nil {
+		return animals
+	}
+
+	keys := animalSortKeys(params, searchTerm(c))
+	return pagination.NextPage(c, string(params.Order), animals, func(a models.Animal) []string {
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, key.value(a))
+		}
+		return values
+	})
+}
+
+// sortKey is an expression the listing is ordered by together with a way to
+// read its value back from a loaded row for the keyset cursor.
+type sortKey struct {
+	pagination.KeysetColumn
+	value func(a models.Animal) string
+}
+
+func animalSortKeys(params sorting.Params, q string) []sortKey {
+	createdAt := func(a models.Animal) string { return a.CreatedAt.Format(time.RFC3339Nano) }
+	age := func(a models.Animal) string { return strconv.FormatFloat(float64(a.Age), 'g', -1, 32) }
+
+	var keys []sortKey
+	switch params.Order {
+	case sorting.Oldest:
+		keys = []sortKey{{pagination.KeysetColumn{Expr: "animals.created_at", Type: "timestamptz"}, createdAt}}
+	case sorting.AgeAsc:
+		keys = []sortKey{{pagination.KeysetColumn{Expr: "animals.age", Type: "real"}, age}}
+	case sorting.AgeDesc:
+		keys = []sortKey{{pagination.KeysetColumn{Expr: "animals.age", Type: "real", Desc: true}, age}}
+	case sorting.Name:
+		// The cursor keeps the name as lowered by the database, lowering it
+		// in Go differs for some letters and would skip or repeat rows.
+		keys = []sortKey{{
+			pagination.KeysetColumn{Expr: sortNameExpr, Type: "text"},
+			func(a models.Animal) string { return a.SortName },
+		}}
+	case sorting.Distance:
+		distance, args := distanceExpr(*params.Latitude, *params.Longitude)
+		keys = []sortKey{{
+			pagination.KeysetColumn{Expr: "coalesce(" + distance + ", 'Infinity'::float8)", Args: args, Type: "float8"},
+			func(a models.Animal) string {
+				if a.Distance == nil {
+					return "Infinity"
+				}
+				return strconv.FormatFloat(*a.Distance, 'g', -1, 64)
+			},
+		}}
+	case sorting.Relevance:
+		rank, args := rankExpr(q)
+		keys = []sortKey{{
+			pagination.KeysetColumn{Expr: rank, Args: args, Type: "real", Desc: true},
+			func(a models.Animal) string { return strconv.FormatFloat(float64(a.SearchRank), 'g', -1, 32) },
+		}}
+	default:
+		keys = []sortKey{{pagination.KeysetColumn{Expr: "animals.created_at", Type: "timestamptz", Desc: true}, createdAt}}
+	}
+
+	// The tie-breaker follows the direction of the primary key.
+	return append(keys, sortKey{
+		pagination.KeysetColumn{Expr: "animals.id", Type: "bigint", Desc: keys[0].Desc},
+		func(a models.Animal) string { return strconv.FormatUint(uint64(a.ID), 10) },
+	})
+}
+
+func keysetColumns(keys []sortKey) []pagination.KeysetColumn {
+	columns := make([]pagination.KeysetColumn, 0, len(keys))
+	for _, key := range keys {
+		columns = append(columns, key.KeysetColumn)
+	}
+	return columns
+}
+
+func searchTerm(c *gin.Context) string {
+	return strings.TrimSpace(c.Query(constants.SearchParam))
+}
+
+func rankExpr(q string) (string, []interface{}) {
+	return "ts_rank(animals.search_vector, websearch_to_tsquery(?, ?))", []interface{}{constants.SearchLanguage, q}
+}
+
+// sortNameExpr is what listings sorted by name are ordered by.
+const sortNameExpr = "lower(animals.name)"
+
+// escapeHTMLExpr escapes the text column as HTML. Highlights are returned as
+// HTML with the matches in <mark> tags, so the user's text around them must
+// not be markup of its own.
+func escapeHTMLExpr(column string) string {
+	return `replace(replace(replace(replace(replace(` + column +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// distanceExpr returns the great-circle distance in kilometers between the
+// given point and the animal, or NULL when the animal has no coordinates.
+func distanceExpr(lat, lng float64) (string, []interface{}) {
+	return `(6371 * acos(least(1.0, greatest(-1.0,
+		cos(radians(?)) * cos(radians(animals.latitude)) * cos(radians(animals.longitude) - radians(?)) +
+		sin(radians(?)) * sin(radians(animals.latitude))))))`, []interface{}{lat, lng, lat}
+}
+
 func (s *AnimalStore) addMediaPreload(db *gorm.DB) *gorm.DB {
-	return db.Preload("Photos").Preload("Image")
+	return db.Preload("Photos", func(db *gorm.DB) *gorm.DB {
+		return db.Order("photos.position, photos.id")
+	}).Preload("Image")
 }
@@ -0,0 +1,161 @@
+package conversations
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const messagesOrder = "messages"
+
+var messagesColumns = []pagination.KeysetColumn{{Expr: "messages.id", Type: "bigint", Desc: true}}
+
+type ConversationStoreI interface {
+	FindConversation(animalID uint, adopterID uuid.UUID) (models.Conversation, error)
+	CreateConversation(conversation *models.Conversation) error
+	GetConversation(id uint) (models.Conversation, error)
+	GetConversations(userID uuid.UUID, c *gin.Context) ([]models.Conversation, error)
+	GetMessages(conversationID uint, c *gin.Context) ([]models.Message, error)
+	AddMessage(message *models.Message) error
+	MarkAsRead(conversation models.Conversation, userID uuid.UUID) error
+	CountMessagesSince(senderID uuid.UUID, since time.Time) (int64, error)
+	CountConversationsSince(adopterID uuid.UUID, since time.Time) (int64, error)
+	Block(blockerID, blockedID uuid.UUID) error
+	IsBlocked(a, b uuid.UUID) (bool, error)
+	AddReport(report *models.Report) error
+}
+
+type ConversationStore struct {
+	db *gorm.DB
+}
+
+func NewConversationStore(db *gorm.DB) *ConversationStore {
+	return &ConversationStore{db: db}
+}
+
+func (s *ConversationStore) FindConversation(animalID uint, adopterID uuid.UUID) (models.Conversation, error) {
+	var conversation models.Conversation
+	err := s.db.Where("animal_id = ? AND adopter_id = ?", animalID, adopterID).Take(&conversation).Error
+	return conversation, err
+}
+
+// CreateConversation creates the conversation, or loads it if the adopter
+// already started one about the animal.
+func (s *ConversationStore) CreateConversation(conversation *models.Conversation) error {
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(conversation)
+	if result.Error != nil || result.RowsAffected == 1 {
+		return result.Error
+	}
+	return s.db.Where("animal_id = ? AND adopter_id = ?", conversation.AnimalID, conversation.AdopterID).Take(conversation).Error
+}
+
+func (s *ConversationStore) GetConversation(id uint) (models.Conversation, error) {
+	var conversation models.Conversation
+	err := s.db.Take(&conversation, id).Error
+	return conversation, err
+}
+
+// GetConversations returns a page of the user's conversations, the most
+// recently active first.
+func (s *ConversationStore) GetConversations(userID uuid.UUID, c *gin.Context) ([]models.Conversation, error) {
+	var conversations []models.Conversation
+	err := s.db.
+		Where("adopter_id = ? OR owner_id = ?", userID, userID).
+		Order("last_message_at DESC, id DESC").
+		Scopes(pagination.Paginate(c)).
+		Find(&conversations).Error
+	return conversations, err
+}
+
+// GetMessages returns a page of the conversation, newest first.
+func (s *ConversationStore) GetMessages(conversationID uint, c *gin.Context) ([]models.Message, error) {
+	var messages []models.Message
+	paginate := pagination.Paginate(c)
+	if pagination.IsCursorMode(c) {
+		paginate = pagination.PaginateKeyset(c, messagesOrder, messagesColumns)
+	}
+
+	err := s.db.
+		Where("conversation_id = ?", conversationID).
+		Clauses(pagination.OrderBy(messagesColumns)).
+		Scopes(paginate).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if !pagination.IsCursorMode(c) {
+		return messages, nil
+	}
+	return pagination.NextPage(c, messagesOrder, messages, func(m models.Message) []string {
+		return []string{strconv.FormatUint(uint64(m.ID), 10)}
+	}), nil
+}
+
+// AddMessage stores the message and bumps the conversation. Senders have
+// read their own messages.
+func (s *ConversationStore) AddMessage(message *models.Message) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+
+		var conversation models.Conversation
+		if err := tx.Take(&conversation, message.ConversationID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&conversation).UpdateColumns(map[string]interface{}{
+			"last_message_at": message.CreatedAt,
+			lastReadColumn(conversation, message.SenderID): message.ID,
+		}).Error
+	})
+}
+
+// MarkAsRead marks every message of the conversation as read by the user.
+func (s *ConversationStore) MarkAsRead(conversation models.Conversation, userID uuid.UUID) error {
+	latest := s.db.Model(&models.Message{}).Select("coalesce(max(id), 0)").Where("conversation_id = ?", conversation.ID)
+	return s.db.Model(&conversation).UpdateColumn(lastReadColumn(conversation, userID), latest).Error
+}
+
+func lastReadColumn(conversation models.Conversation, userID uuid.UUID) string {
+	if conversation.AdopterID == userID {
+		return "adopter_last_read_id"
+	}
+	return "owner_last_read_id"
+}
+
+func (s *ConversationStore) CountMessagesSince(senderID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Message{}).Where("sender_id = ? AND created_at >= ?", senderID, since).Count(&count).Error
+	return count, err
+}
+
+func (s *ConversationStore) CountConversationsSince(adopterID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Conversation{}).Where("adopter_id = ? AND created_at >= ?", adopterID, since).Count(&count).Error
+	return count, err
+}
+
+func (s *ConversationStore) Block(blockerID, blockedID uuid.UUID) error {
+	block := models.Block{BlockerID: blockerID, BlockedID: blockedID}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&block).Error
+}
+
+// IsBlocked reports whether either user blocked the other.
+func (s *ConversationStore) IsBlocked(a, b uuid.UUID) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", a, b, b, a).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (s *ConversationStore) AddReport(report *models.Report) error {
+	return s.db.Create(report).Error
+}
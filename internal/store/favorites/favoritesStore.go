@@ -0,0 +1,58 @@
+package favorites
+
+import (
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteStoreI interface {
+	AddFavorite(favorite *models.Favorite) (bool, error)
+	RemoveFavorite(userID uuid.UUID, animalID uint) error
+}
+
+type FavoriteStore struct {
+	db *gorm.DB
+}
+
+func NewFavoriteStore(db *gorm.DB) *FavoriteStore {
+	return &FavoriteStore{db: db}
+}
+
+// AddFavorite stores the favorite, replacing the note if the animal is already
+// a favorite. It reports whether the favorite is new.
+func (s *FavoriteStore) AddFavorite(favorite *models.Favorite) (bool, error) {
+	created := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "animal_id"}},
+			DoNothing: true,
+		}).Create(favorite)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			created = true
+			return nil
+		}
+
+		note := favorite.Note
+		if err := tx.Where("user_id = ? AND animal_id = ?", favorite.UserID, favorite.AnimalID).First(favorite).Error; err != nil {
+			return err
+		}
+		return tx.Model(favorite).Update("note", note).Error
+	})
+	return created, err
+}
+
+func (s *FavoriteStore) RemoveFavorite(userID uuid.UUID, animalID uint) error {
+	result := s.db.Where("user_id = ? AND animal_id = ?", userID, animalID).Delete(&models.Favorite{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
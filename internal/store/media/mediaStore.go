@@ -0,0 +1,59 @@
+package media
+
+import (
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"gorm.io/gorm"
+)
+
+type MediaStoreI interface {
+	ReferencedKeys() (map[string]bool, error)
+}
+
+type MediaStore struct {
+	db *gorm.DB
+}
+
+func NewMediaStore(db *gorm.DB) *MediaStore {
+	return &MediaStore{db: db}
+}
+
+// ReferencedKeys returns the keys of every stored file the database still
+// refers to: the images and photos of animals that are not deleted, with
+// their variants, and message attachments with theirs.
+func (s *MediaStore) ReferencedKeys() (map[string]bool, error) {
+	animals := s.db.Model(&models.Animal{}).Select("id")
+	columns := []string{"key", "variant_thumb_key", "variant_card_key"}
+
+	var images []models.Image
+	if err := s.db.Select(columns).Where("animal_id IN (?)", animals).Find(&images).Error; err != nil {
+		return nil, err
+	}
+	var photos []models.Photo
+	if err := s.db.Select(columns).Where("animal_id IN (?)", animals).Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	var messages []models.Message
+	err := s.db.Select("attachment_key", "attachment_variant_thumb_key", "attachment_variant_card_key").
+		Where("attachment_key <> ''").Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, 3*(len(images)+len(photos)+len(messages)))
+	for _, image := range images {
+		for _, key := range image.Keys() {
+			keys[key] = true
+		}
+	}
+	for _, photo := range photos {
+		for _, key := range photo.Keys() {
+			keys[key] = true
+		}
+	}
+	for _, message := range messages {
+		for _, key := range message.AttachmentKeys() {
+			keys[key] = true
+		}
+	}
+	return keys, nil
+}
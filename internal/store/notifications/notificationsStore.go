@@ -0,0 +1,94 @@
+package notifications
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const inboxOrder = "inbox"
+
+var inboxColumns = []pagination.KeysetColumn{{Expr: "notifications.id", Type: "bigint", Desc: true}}
+
+type NotificationStoreI interface {
+	CreateNotifications(notifications []models.Notification) error
+	GetNotifications(userID uuid.UUID, c *gin.Context) ([]models.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkAsRead(userID uuid.UUID, id uint) error
+	MarkAllAsRead(userID uuid.UUID) error
+}
+
+type NotificationStore struct {
+	db *gorm.DB
+}
+
+func NewNotificationStore(db *gorm.DB) *NotificationStore {
+	return &NotificationStore{db: db}
+}
+
+func (s *NotificationStore) CreateNotifications(notifications []models.Notification) error {
+	return s.db.Create(&notifications).Error
+}
+
+// GetNotifications returns a page of the user's inbox, newest first. Only
+// unread notifications are returned when the request asks for them.
+func (s *NotificationStore) GetNotifications(userID uuid.UUID, c *gin.Context) ([]models.Notification, error) {
+	var notifications []models.Notification
+	paginate := pagination.Paginate(c)
+	if pagination.IsCursorMode(c) {
+		paginate = pagination.PaginateKeyset(c, inboxOrder, inboxColumns)
+	}
+
+	db := s.db.Where("user_id = ?", userID)
+	if unread, _ := strconv.ParseBool(c.Query(constants.UnreadParam)); unread {
+		db = db.Where("read_at IS NULL")
+	}
+
+	err := db.
+		Clauses(pagination.OrderBy(inboxColumns)).
+		Scopes(paginate).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if !pagination.IsCursorMode(c) {
+		return notifications, nil
+	}
+	return pagination.NextPage(c, inboxOrder, notifications, func(n models.Notification) []string {
+		return []string{strconv.FormatUint(uint64(n.ID), 10)}
+	}), nil
+}
+
+func (s *NotificationStore) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead marks the notification as read. Reading it again keeps the
+// original read time.
+func (s *NotificationStore) MarkAsRead(userID uuid.UUID, id uint) error {
+	result := s.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		UpdateColumn("read_at", gorm.Expr("coalesce(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *NotificationStore) MarkAllAsRead(userID uuid.UUID) error {
+	return s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", time.Now()).Error
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxStoreI interface {
+	Enqueue(emails ...models.OutboxEmail) error
+	ClaimDueEmails(now time.Time, limit int, lease time.Duration) ([]models.OutboxEmail, error)
+	UpdateEmail(email *models.OutboxEmail) error
+	IsSuppressed(address string) (bool, error)
+	Suppress(address, reason string) error
+}
+
+type OutboxStore struct {
+	db *gorm.DB
+}
+
+func NewOutboxStore(db *gorm.DB) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// NewEmail prepares a rendered email for the outbox.
+func NewEmail(template string, email mail.Email) models.OutboxEmail {
+	return models.OutboxEmail{
+		Recipient:     email.To,
+		Template:      template,
+		Subject:       email.Subject,
+		Text:          email.Text,
+		HTML:          email.HTML,
+		Status:        models.EmailPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// Enqueue writes the emails to the outbox with tx. Stores call it inside
+// their own transactions so that the emails are committed together with the
+// change that triggered them.
+func Enqueue(tx *gorm.DB, emails ...models.OutboxEmail) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	return tx.Create(&emails).Error
+}
+
+func (s *OutboxStore) Enqueue(emails ...models.OutboxEmail) error {
+	return Enqueue(s.db, emails...)
+}
+
+// ClaimDueEmails locks up to limit pending emails that are due and pushes
+// their next attempt past the lease, so that other dispatchers skip them
+// while they are being sent.
+func (s *OutboxStore) ClaimDueEmails(now time.Time, limit int, lease time.Duration) ([]models.OutboxEmail, error) {
+	var emails []models.OutboxEmail
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.EmailPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&emails).Error
+		if err != nil || len(emails) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(emails))
+		for _, e := range emails {
+			ids = append(ids, e.ID)
+		}
+		return tx.Model(&models.OutboxEmail{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	return emails, err
+}
+
+func (s *OutboxStore) UpdateEmail(email *models.OutboxEmail) error {
+	return s.db.Save(email).Error
+}
+
+func (s *OutboxStore) IsSuppressed(address string) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.EmailSuppression{}).Where("address = ?", address).Count(&count).Error
+	return count > 0, err
+}
+
+// Suppress stops all further emails to the address.
+func (s *OutboxStore) Suppress(address, reason string) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.EmailSuppression{Address: address, Reason: reason}).Error
+}
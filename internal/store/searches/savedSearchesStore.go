@@ -0,0 +1,100 @@
+package searches
+
+import (
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedSearchStoreI interface {
+	CreateSavedSearch(search *models.SavedSearch) error
+	GetSavedSearches(userID uuid.UUID) ([]models.SavedSearch, error)
+	DeleteSavedSearch(userID uuid.UUID, id uint) error
+	GetDueSavedSearches(lastRunBefore time.Time, after *models.SavedSearch, limit int) ([]models.SavedSearch, error)
+	ClaimRun(search *models.SavedSearch, now time.Time) (bool, error)
+	ReleaseRun(search *models.SavedSearch, claimedAt time.Time) error
+	Unsubscribe(token string) error
+}
+
+type SavedSearchStore struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchStore(db *gorm.DB) *SavedSearchStore {
+	return &SavedSearchStore{db: db}
+}
+
+func (s *SavedSearchStore) CreateSavedSearch(search *models.SavedSearch) error {
+	return s.db.Create(search).Error
+}
+
+func (s *SavedSearchStore) GetSavedSearches(userID uuid.UUID) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := s.db.Where("user_id = ?", userID).Order("id").Find(&searches).Error
+	return searches, err
+}
+
+func (s *SavedSearchStore) DeleteSavedSearch(userID uuid.UUID, id uint) error {
+	result := s.db.Where("user_id = ?", userID).Delete(&models.SavedSearch{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDueSavedSearches returns up to limit searches with at least one delivery
+// channel enabled that were last run before the given time, in the order
+// they were last run. Pages continue after the last search of the previous
+// one, so that searches whose run failed are not returned again.
+func (s *SavedSearchStore) GetDueSavedSearches(lastRunBefore time.Time, after *models.SavedSearch, limit int) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	db := s.db
+	if after != nil {
+		db = db.Where("(last_run_at, id) > (?, ?)", after.LastRunAt, after.ID)
+	}
+	err := db.
+		Where("last_run_at <= ?", lastRunBefore).
+		Where("notify_in_app OR notify_email").
+		Order("last_run_at, id").
+		Limit(limit).
+		Find(&searches).Error
+	return searches, err
+}
+
+// ClaimRun moves the last run of the search to now unless another instance of
+// the job already did, so that every run is delivered once. The previous last
+// run is kept on the search.
+func (s *SavedSearchStore) ClaimRun(search *models.SavedSearch, now time.Time) (bool, error) {
+	result := s.db.Model(&models.SavedSearch{}).
+		Where("id = ? AND last_run_at = ?", search.ID, search.LastRunAt).
+		UpdateColumn("last_run_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseRun moves the last run of the search back from claimedAt to the
+// previous one kept on the search, so that a failed run is retried over the
+// same time window.
+func (s *SavedSearchStore) ReleaseRun(search *models.SavedSearch, claimedAt time.Time) error {
+	return s.db.Model(&models.SavedSearch{}).
+		Where("id = ? AND last_run_at = ?", search.ID, claimedAt).
+		UpdateColumn("last_run_at", search.LastRunAt).Error
+}
+
+// Unsubscribe turns off email digests for the search the token belongs to.
+func (s *SavedSearchStore) Unsubscribe(token string) error {
+	result := s.db.Model(&models.SavedSearch{}).
+		Where("unsubscribe_token = ?", token).
+		UpdateColumn("notify_email", false)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
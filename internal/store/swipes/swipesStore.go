@@ -0,0 +1,110 @@
+package swipes
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNothingToUndo = errors.New("no swipe to undo")
+
+const historyOrder = "history"
+
+var historyColumns = []pagination.KeysetColumn{{Expr: "swipe_events.id", Type: "bigint", Desc: true}}
+
+type SwipeStoreI interface {
+	GetSwipeHistory(userID uuid.UUID, c *gin.Context) ([]models.SwipeEvent, error)
+	UndoLastSwipe(userID uuid.UUID, window time.Duration, client string) (models.SwipeEvent, error)
+}
+
+type SwipeStore struct {
+	db *gorm.DB
+}
+
+func NewSwipeStore(db *gorm.DB) *SwipeStore {
+	return &SwipeStore{db: db}
+}
+
+func (s *SwipeStore) GetSwipeHistory(userID uuid.UUID, c *gin.Context) ([]models.SwipeEvent, error) {
+	var events []models.SwipeEvent
+	paginate := pagination.Paginate(c)
+	if pagination.IsCursorMode(c) {
+		paginate = pagination.PaginateKeyset(c, historyOrder, historyColumns)
+	}
+
+	err := s.db.
+		Where("user_id = ?", userID).
+		Clauses(pagination.OrderBy(historyColumns)).
+		Scopes(paginate).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if !pagination.IsCursorMode(c) {
+		return events, nil
+	}
+	return pagination.NextPage(c, historyOrder, events, func(e models.SwipeEvent) []string {
+		return []string{strconv.FormatUint(uint64(e.ID), 10)}
+	}), nil
+}
+
+// UndoLastSwipe reverts the most recent swipe of the user if it happened within
+// the window: seen_animals goes back to its previous state and a favorite added
+// by the swipe is removed. The undo is itself appended to the event log.
+func (s *SwipeStore) UndoLastSwipe(userID uuid.UUID, window time.Duration, client string) (models.SwipeEvent, error) {
+	var last models.SwipeEvent
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			Order("id DESC").
+			Take(&last).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNothingToUndo
+		}
+		if err != nil {
+			return err
+		}
+		if last.Action == models.SwipeActionUndo || time.Since(last.CreatedAt) > window {
+			return ErrNothingToUndo
+		}
+
+		seen := tx.Model(&models.SeenAnimal{}).Where("user_id = ? AND animal_id = ?", userID, last.AnimalID)
+		if last.PreviousSeenAt == nil {
+			err = seen.Delete(&models.SeenAnimal{}).Error
+		} else {
+			// UpdateColumns skips the hooks that would stamp seen_at with the current time.
+			err = seen.UpdateColumns(map[string]interface{}{
+				"liked":   *last.PreviousLiked,
+				"seen_at": *last.PreviousSeenAt,
+			}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if last.AddedFavorite {
+			err := tx.Where("user_id = ? AND animal_id = ?", userID, last.AnimalID).Delete(&models.Favorite{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&models.SwipeEvent{
+			UserID:        userID,
+			AnimalID:      last.AnimalID,
+			Action:        models.SwipeActionUndo,
+			Client:        client,
+			UndoneEventID: &last.ID,
+		}).Error
+	})
+
+	return last, err
+}
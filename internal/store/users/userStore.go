@@ -1,17 +1,20 @@
 package users
 
 import (
+	"github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox"
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserStoreI interface {
-	Create(user *models.User) error
+	Create(user *models.User, emails ...models.OutboxEmail) error
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetUserSettings(id uuid.UUID) (models.UserSettings, error)
 	SetUserSettings(userID uuid.UUID, newSettings models.UserSettings) error
+	UpdateUser(user *models.User) error
 }
 
 type UserStore struct {
@@ -22,10 +25,14 @@ func NewUserStore(db *gorm.DB) *UserStore {
 	return &UserStore{db: db}
 }
 
-func (s *UserStore) Create(user *models.User) error {
-	result := s.db.Create(&user)
-
-	return result.Error
+// Create creates the user together with the emails the signup sends.
+func (s *UserStore) Create(user *models.User, emails ...models.OutboxEmail) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, emails...)
+	})
 }
 
 func (s *UserStore) GetByEmail(email string) (*models.User, error) {
@@ -40,6 +47,11 @@ func (s *UserStore) GetByID(id uuid.UUID) (*models.User, error) {
 	return user, result.Error
 }
 
+// UpdateUser saves the fields of the user itself, not its associations.
+func (s *UserStore) UpdateUser(user *models.User) error {
+	return s.db.Omit(clause.Associations).Save(user).Error
+}
+
 func (s *UserStore) SetUserSettings(userID uuid.UUID, newSettings models.UserSettings) error {
 	var settings models.UserSettings
 	if err := s.db.First(&settings, "user_id = ?", userID).Error; err != nil {
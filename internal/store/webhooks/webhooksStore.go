@@ -0,0 +1,159 @@
+package webhooks
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const deliveriesOrder = "deliveries"
+
+var deliveriesColumns = []pagination.KeysetColumn{{Expr: "webhook_deliveries.id", Type: "bigint", Desc: true}}
+
+type WebhookStoreI interface {
+	CreateWebhook(webhook *models.Webhook) error
+	GetWebhooks(userID uuid.UUID) ([]models.Webhook, error)
+	GetWebhook(userID uuid.UUID, id uint) (models.Webhook, error)
+	DeleteWebhook(userID uuid.UUID, id uint) error
+	GetSubscribedWebhooks(userID uuid.UUID, eventType string) ([]models.Webhook, error)
+	CreateDeliveries(deliveries []models.WebhookDelivery) error
+	ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+	GetDeliveries(webhookID uint, c *gin.Context) ([]models.WebhookDelivery, error)
+}
+
+type WebhookStore struct {
+	db *gorm.DB
+}
+
+func NewWebhookStore(db *gorm.DB) *WebhookStore {
+	return &WebhookStore{db: db}
+}
+
+func (s *WebhookStore) CreateWebhook(webhook *models.Webhook) error {
+	return s.db.Create(webhook).Error
+}
+
+func (s *WebhookStore) GetWebhooks(userID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := s.db.Where("user_id = ?", userID).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (s *WebhookStore) GetWebhook(userID uuid.UUID, id uint) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := s.db.Where("user_id = ?", userID).Take(&webhook, id).Error
+	return webhook, err
+}
+
+func (s *WebhookStore) DeleteWebhook(userID uuid.UUID, id uint) error {
+	result := s.db.Where("user_id = ?", userID).Delete(&models.Webhook{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetSubscribedWebhooks returns the user's active webhooks subscribed to the event.
+func (s *WebhookStore) GetSubscribedWebhooks(userID uuid.UUID, eventType string) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := s.db.
+		Where("user_id = ? AND active", userID).
+		Where("? = ANY(string_to_array(events, ','))", eventType).
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (s *WebhookStore) CreateDeliveries(deliveries []models.WebhookDelivery) error {
+	return s.db.Create(&deliveries).Error
+}
+
+// ClaimDueDeliveries locks up to limit pending deliveries that are due and
+// pushes their next attempt past the lease, so that other workers skip them
+// while they are being sent. The webhooks are preloaded.
+func (s *WebhookStore) ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(deliveries) == 0 {
+		return deliveries, err
+	}
+
+	return deliveries, s.loadWebhooks(deliveries)
+}
+
+// loadWebhooks sets the webhook of every delivery. Deleted webhooks are not
+// loaded, leaving Webhook nil.
+func (s *WebhookStore) loadWebhooks(deliveries []models.WebhookDelivery) error {
+	ids := make([]uint, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.WebhookID)
+	}
+	var webhooks []models.Webhook
+	if err := s.db.Where("id IN ?", ids).Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Webhook, len(webhooks))
+	for i := range webhooks {
+		byID[webhooks[i].ID] = &webhooks[i]
+	}
+	for i := range deliveries {
+		deliveries[i].Webhook = byID[deliveries[i].WebhookID]
+	}
+	return nil
+}
+
+func (s *WebhookStore) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return s.db.Omit(clause.Associations).Save(delivery).Error
+}
+
+// GetDeliveries returns a page of the webhook's delivery log, newest first.
+func (s *WebhookStore) GetDeliveries(webhookID uint, c *gin.Context) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	paginate := pagination.Paginate(c)
+	if pagination.IsCursorMode(c) {
+		paginate = pagination.PaginateKeyset(c, deliveriesOrder, deliveriesColumns)
+	}
+
+	err := s.db.
+		Where("webhook_id = ?", webhookID).
+		Clauses(pagination.OrderBy(deliveriesColumns)).
+		Scopes(paginate).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if !pagination.IsCursorMode(c) {
+		return deliveries, nil
+	}
+	return pagination.NextPage(c, deliveriesOrder, deliveries, func(d models.WebhookDelivery) []string {
+		return []string{strconv.FormatUint(uint64(d.ID), 10)}
+	}), nil
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	uploads "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,17 +38,77 @@ func (m *MockAnimalServiceI) EXPECT() *MockAnimalServiceIMockRecorder {
 }
 
 // AddAnimal mocks base method.
-func (m *MockAnimalServiceI) AddAnimal(arg0 *models.AnimalJSON) error {
+func (m *MockAnimalServiceI) AddAnimal(arg0 uuid.UUID, arg1 *models.AnimalJSON) ([]models.DuplicateWarningJSON, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAnimal", arg0)
+	ret := m.ctrl.Call(m, "AddAnimal", arg0, arg1)
+	ret0, _ := ret[0].([]models.DuplicateWarningJSON)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAnimal indicates an expected call of AddAnimal.
+func (mr *MockAnimalServiceIMockRecorder) AddAnimal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnimal", reflect.TypeOf((*MockAnimalServiceI)(nil).AddAnimal), arg0, arg1)
+}
+
+// AddAnimalWithFiles mocks base method.
+func (m *MockAnimalServiceI) AddAnimalWithFiles(arg0 uuid.UUID, arg1 *models.AnimalJSON, arg2 uploads.FileReaderI) ([]models.DuplicateWarningJSON, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAnimalWithFiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.DuplicateWarningJSON)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAnimalWithFiles indicates an expected call of AddAnimalWithFiles.
+func (mr *MockAnimalServiceIMockRecorder) AddAnimalWithFiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnimalWithFiles", reflect.TypeOf((*MockAnimalServiceI)(nil).AddAnimalWithFiles), arg0, arg1, arg2)
+}
+
+// AddPhotosWithFiles mocks base method.
+func (m *MockAnimalServiceI) AddPhotosWithFiles(arg0 uuid.UUID, arg1 string, arg2 uploads.FileReaderI) ([]models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhotosWithFiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPhotosWithFiles indicates an expected call of AddPhotosWithFiles.
+func (mr *MockAnimalServiceIMockRecorder) AddPhotosWithFiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhotosWithFiles", reflect.TypeOf((*MockAnimalServiceI)(nil).AddPhotosWithFiles), arg0, arg1, arg2)
+}
+
+// ConfirmPhotoUploads mocks base method.
+func (m *MockAnimalServiceI) ConfirmPhotoUploads(arg0 uuid.UUID, arg1 string, arg2 []string) ([]models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPhotoUploads", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPhotoUploads indicates an expected call of ConfirmPhotoUploads.
+func (mr *MockAnimalServiceIMockRecorder) ConfirmPhotoUploads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPhotoUploads", reflect.TypeOf((*MockAnimalServiceI)(nil).ConfirmPhotoUploads), arg0, arg1, arg2)
+}
+
+// DeletePhoto mocks base method.
+func (m *MockAnimalServiceI) DeletePhoto(arg0 uuid.UUID, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddAnimal indicates an expected call of AddAnimal.
-func (mr *MockAnimalServiceIMockRecorder) AddAnimal(arg0 interface{}) *gomock.Call {
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockAnimalServiceIMockRecorder) DeletePhoto(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnimal", reflect.TypeOf((*MockAnimalServiceI)(nil).AddAnimal), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockAnimalServiceI)(nil).DeletePhoto), arg0, arg1, arg2)
 }
 
 // GetAllAnimals mocks base method.
@@ -111,15 +172,87 @@ func (mr *MockAnimalServiceIMockRecorder) GetLikedAnimals(arg0, arg1 interface{}
 }
 
 // MarkAsSeen mocks base method.
-func (m *MockAnimalServiceI) MarkAsSeen(arg0 string, arg1 uuid.UUID, arg2 bool) error {
+func (m *MockAnimalServiceI) MarkAsSeen(arg0 string, arg1 uuid.UUID, arg2 bool, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsSeen", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "MarkAsSeen", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkAsSeen indicates an expected call of MarkAsSeen.
-func (mr *MockAnimalServiceIMockRecorder) MarkAsSeen(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAnimalServiceIMockRecorder) MarkAsSeen(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSeen", reflect.TypeOf((*MockAnimalServiceI)(nil).MarkAsSeen), arg0, arg1, arg2, arg3)
+}
+
+// NotifyLiked mocks base method.
+func (m *MockAnimalServiceI) NotifyLiked(arg0 uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyLiked", arg0)
+}
+
+// NotifyLiked indicates an expected call of NotifyLiked.
+func (mr *MockAnimalServiceIMockRecorder) NotifyLiked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyLiked", reflect.TypeOf((*MockAnimalServiceI)(nil).NotifyLiked), arg0)
+}
+
+// PresignPhotoUploads mocks base method.
+func (m *MockAnimalServiceI) PresignPhotoUploads(arg0 uuid.UUID, arg1 string, arg2 []models.PhotoUploadJSON) ([]models.PresignedUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignPhotoUploads", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.PresignedUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignPhotoUploads indicates an expected call of PresignPhotoUploads.
+func (mr *MockAnimalServiceIMockRecorder) PresignPhotoUploads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignPhotoUploads", reflect.TypeOf((*MockAnimalServiceI)(nil).PresignPhotoUploads), arg0, arg1, arg2)
+}
+
+// ReorderPhotos mocks base method.
+func (m *MockAnimalServiceI) ReorderPhotos(arg0 uuid.UUID, arg1 string, arg2 []models.PhotoPositionJSON) (models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPhotos", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderPhotos indicates an expected call of ReorderPhotos.
+func (mr *MockAnimalServiceIMockRecorder) ReorderPhotos(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPhotos", reflect.TypeOf((*MockAnimalServiceI)(nil).ReorderPhotos), arg0, arg1, arg2)
+}
+
+// SetCover mocks base method.
+func (m *MockAnimalServiceI) SetCover(arg0 uuid.UUID, arg1 string, arg2 uint) (models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCover", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCover indicates an expected call of SetCover.
+func (mr *MockAnimalServiceIMockRecorder) SetCover(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCover", reflect.TypeOf((*MockAnimalServiceI)(nil).SetCover), arg0, arg1, arg2)
+}
+
+// UpdateAnimal mocks base method.
+func (m *MockAnimalServiceI) UpdateAnimal(arg0 uuid.UUID, arg1 string, arg2 *models.AnimalUpdateJSON) (models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnimal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnimal indicates an expected call of UpdateAnimal.
+func (mr *MockAnimalServiceIMockRecorder) UpdateAnimal(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSeen", reflect.TypeOf((*MockAnimalServiceI)(nil).MarkAsSeen), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnimal", reflect.TypeOf((*MockAnimalServiceI)(nil).UpdateAnimal), arg0, arg1, arg2)
 }
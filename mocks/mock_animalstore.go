@@ -5,9 +5,12 @@
 package mocks
 
 import (
+	url "net/url"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	ranking "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
 	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -64,6 +67,63 @@ func (mr *MockAnimalStoreIMockRecorder) AddAnimals(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAnimals", reflect.TypeOf((*MockAnimalStoreI)(nil).AddAnimals), arg0)
 }
 
+// AddModerationItems mocks base method.
+func (m *MockAnimalStoreI) AddModerationItems(arg0 []models.ModerationItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddModerationItems", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddModerationItems indicates an expected call of AddModerationItems.
+func (mr *MockAnimalStoreIMockRecorder) AddModerationItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddModerationItems", reflect.TypeOf((*MockAnimalStoreI)(nil).AddModerationItems), arg0)
+}
+
+// AddPhotos mocks base method.
+func (m *MockAnimalStoreI) AddPhotos(arg0 []models.Photo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPhotos", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPhotos indicates an expected call of AddPhotos.
+func (mr *MockAnimalStoreIMockRecorder) AddPhotos(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPhotos", reflect.TypeOf((*MockAnimalStoreI)(nil).AddPhotos), arg0)
+}
+
+// DeletePhoto mocks base method.
+func (m *MockAnimalStoreI) DeletePhoto(arg0 models.Photo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockAnimalStoreIMockRecorder) DeletePhoto(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockAnimalStoreI)(nil).DeletePhoto), arg0)
+}
+
+// FindSimilarAnimals mocks base method.
+func (m *MockAnimalStoreI) FindSimilarAnimals(arg0 []int64, arg1 uint, arg2 int) ([]models.SimilarAnimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilarAnimals", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.SimilarAnimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilarAnimals indicates an expected call of FindSimilarAnimals.
+func (mr *MockAnimalStoreIMockRecorder) FindSimilarAnimals(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilarAnimals", reflect.TypeOf((*MockAnimalStoreI)(nil).FindSimilarAnimals), arg0, arg1, arg2)
+}
+
 // GetAllAnimals mocks base method.
 func (m *MockAnimalStoreI) GetAllAnimals(arg0 *gin.Context) ([]models.Animal, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +139,21 @@ func (mr *MockAnimalStoreIMockRecorder) GetAllAnimals(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAnimals", reflect.TypeOf((*MockAnimalStoreI)(nil).GetAllAnimals), arg0)
 }
 
+// GetAnimalsAfter mocks base method.
+func (m *MockAnimalStoreI) GetAnimalsAfter(arg0 uint, arg1 int) ([]models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnimalsAfter", arg0, arg1)
+	ret0, _ := ret[0].([]models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnimalsAfter indicates an expected call of GetAnimalsAfter.
+func (mr *MockAnimalStoreIMockRecorder) GetAnimalsAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnimalsAfter", reflect.TypeOf((*MockAnimalStoreI)(nil).GetAnimalsAfter), arg0, arg1)
+}
+
 // GetById mocks base method.
 func (m *MockAnimalStoreI) GetById(arg0 string) (models.Animal, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +169,36 @@ func (mr *MockAnimalStoreIMockRecorder) GetById(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAnimalStoreI)(nil).GetById), arg0)
 }
 
+// GetFavoritedBy mocks base method.
+func (m *MockAnimalStoreI) GetFavoritedBy(arg0 uint) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoritedBy", arg0)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoritedBy indicates an expected call of GetFavoritedBy.
+func (mr *MockAnimalStoreIMockRecorder) GetFavoritedBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoritedBy", reflect.TypeOf((*MockAnimalStoreI)(nil).GetFavoritedBy), arg0)
+}
+
+// GetFeedCandidates mocks base method.
+func (m *MockAnimalStoreI) GetFeedCandidates(arg0 uuid.UUID, arg1 *gin.Context, arg2 int) ([]models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedCandidates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedCandidates indicates an expected call of GetFeedCandidates.
+func (mr *MockAnimalStoreIMockRecorder) GetFeedCandidates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedCandidates", reflect.TypeOf((*MockAnimalStoreI)(nil).GetFeedCandidates), arg0, arg1, arg2)
+}
+
 // GetLikedAnimals mocks base method.
 func (m *MockAnimalStoreI) GetLikedAnimals(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Animal, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +214,36 @@ func (mr *MockAnimalStoreIMockRecorder) GetLikedAnimals(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedAnimals", reflect.TypeOf((*MockAnimalStoreI)(nil).GetLikedAnimals), arg0, arg1)
 }
 
+// GetModerationItems mocks base method.
+func (m *MockAnimalStoreI) GetModerationItems(arg0 string, arg1 uint, arg2 int) ([]models.ModerationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationItems", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.ModerationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationItems indicates an expected call of GetModerationItems.
+func (mr *MockAnimalStoreIMockRecorder) GetModerationItems(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationItems", reflect.TypeOf((*MockAnimalStoreI)(nil).GetModerationItems), arg0, arg1, arg2)
+}
+
+// GetNewMatches mocks base method.
+func (m *MockAnimalStoreI) GetNewMatches(arg0 url.Values, arg1, arg2 time.Time, arg3 int) ([]models.Animal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewMatches", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Animal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewMatches indicates an expected call of GetNewMatches.
+func (mr *MockAnimalStoreIMockRecorder) GetNewMatches(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewMatches", reflect.TypeOf((*MockAnimalStoreI)(nil).GetNewMatches), arg0, arg1, arg2, arg3)
+}
+
 // GetNotSeenAnimals mocks base method.
 func (m *MockAnimalStoreI) GetNotSeenAnimals(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Animal, error) {
 	m.ctrl.T.Helper()
@@ -124,16 +259,88 @@ func (mr *MockAnimalStoreIMockRecorder) GetNotSeenAnimals(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotSeenAnimals", reflect.TypeOf((*MockAnimalStoreI)(nil).GetNotSeenAnimals), arg0, arg1)
 }
 
+// GetSwipeSignals mocks base method.
+func (m *MockAnimalStoreI) GetSwipeSignals(arg0 uuid.UUID, arg1 int) ([]ranking.Signal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwipeSignals", arg0, arg1)
+	ret0, _ := ret[0].([]ranking.Signal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwipeSignals indicates an expected call of GetSwipeSignals.
+func (mr *MockAnimalStoreIMockRecorder) GetSwipeSignals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwipeSignals", reflect.TypeOf((*MockAnimalStoreI)(nil).GetSwipeSignals), arg0, arg1)
+}
+
 // MarkAsSeen mocks base method.
-func (m *MockAnimalStoreI) MarkAsSeen(arg0 uint, arg1 uuid.UUID, arg2 bool) error {
+func (m *MockAnimalStoreI) MarkAsSeen(arg0 uint, arg1 uuid.UUID, arg2 bool, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsSeen", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAsSeen indicates an expected call of MarkAsSeen.
+func (mr *MockAnimalStoreIMockRecorder) MarkAsSeen(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSeen", reflect.TypeOf((*MockAnimalStoreI)(nil).MarkAsSeen), arg0, arg1, arg2, arg3)
+}
+
+// ResolveModerationItem mocks base method.
+func (m *MockAnimalStoreI) ResolveModerationItem(arg0 uint, arg1 string, arg2 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsSeen", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ResolveModerationItem", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// MarkAsSeen indicates an expected call of MarkAsSeen.
-func (mr *MockAnimalStoreIMockRecorder) MarkAsSeen(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ResolveModerationItem indicates an expected call of ResolveModerationItem.
+func (mr *MockAnimalStoreIMockRecorder) ResolveModerationItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveModerationItem", reflect.TypeOf((*MockAnimalStoreI)(nil).ResolveModerationItem), arg0, arg1, arg2)
+}
+
+// SaveImage mocks base method.
+func (m *MockAnimalStoreI) SaveImage(arg0 *models.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveImage indicates an expected call of SaveImage.
+func (mr *MockAnimalStoreIMockRecorder) SaveImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImage", reflect.TypeOf((*MockAnimalStoreI)(nil).SaveImage), arg0)
+}
+
+// UpdateAnimal mocks base method.
+func (m *MockAnimalStoreI) UpdateAnimal(arg0 *models.Animal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnimal", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnimal indicates an expected call of UpdateAnimal.
+func (mr *MockAnimalStoreIMockRecorder) UpdateAnimal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnimal", reflect.TypeOf((*MockAnimalStoreI)(nil).UpdateAnimal), arg0)
+}
+
+// UpdatePhotoPositions mocks base method.
+func (m *MockAnimalStoreI) UpdatePhotoPositions(arg0 []models.Photo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhotoPositions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhotoPositions indicates an expected call of UpdatePhotoPositions.
+func (mr *MockAnimalStoreIMockRecorder) UpdatePhotoPositions(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSeen", reflect.TypeOf((*MockAnimalStoreI)(nil).MarkAsSeen), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhotoPositions", reflect.TypeOf((*MockAnimalStoreI)(nil).UpdatePhotoPositions), arg0)
 }
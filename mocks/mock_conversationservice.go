@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: ConversationServiceI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockConversationServiceI is a mock of ConversationServiceI interface.
+type MockConversationServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationServiceIMockRecorder
+}
+
+// MockConversationServiceIMockRecorder is the mock recorder for MockConversationServiceI.
+type MockConversationServiceIMockRecorder struct {
+	mock *MockConversationServiceI
+}
+
+// NewMockConversationServiceI creates a new mock instance.
+func NewMockConversationServiceI(ctrl *gomock.Controller) *MockConversationServiceI {
+	mock := &MockConversationServiceI{ctrl: ctrl}
+	mock.recorder = &MockConversationServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationServiceI) EXPECT() *MockConversationServiceIMockRecorder {
+	return m.recorder
+}
+
+// BlockParticipant mocks base method.
+func (m *MockConversationServiceI) BlockParticipant(arg0 uuid.UUID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockParticipant", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockParticipant indicates an expected call of BlockParticipant.
+func (mr *MockConversationServiceIMockRecorder) BlockParticipant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockParticipant", reflect.TypeOf((*MockConversationServiceI)(nil).BlockParticipant), arg0, arg1)
+}
+
+// GetConversations mocks base method.
+func (m *MockConversationServiceI) GetConversations(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversations", arg0, arg1)
+	ret0, _ := ret[0].([]models.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversations indicates an expected call of GetConversations.
+func (mr *MockConversationServiceIMockRecorder) GetConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversations", reflect.TypeOf((*MockConversationServiceI)(nil).GetConversations), arg0, arg1)
+}
+
+// GetMessages mocks base method.
+func (m *MockConversationServiceI) GetMessages(arg0 uuid.UUID, arg1 string, arg2 *gin.Context) ([]models.Message, models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(models.Conversation)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockConversationServiceIMockRecorder) GetMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockConversationServiceI)(nil).GetMessages), arg0, arg1, arg2)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockConversationServiceI) MarkAsRead(arg0 uuid.UUID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockConversationServiceIMockRecorder) MarkAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockConversationServiceI)(nil).MarkAsRead), arg0, arg1)
+}
+
+// ReportParticipant mocks base method.
+func (m *MockConversationServiceI) ReportParticipant(arg0 uuid.UUID, arg1 string, arg2 *models.ReportJSON) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportParticipant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportParticipant indicates an expected call of ReportParticipant.
+func (mr *MockConversationServiceIMockRecorder) ReportParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportParticipant", reflect.TypeOf((*MockConversationServiceI)(nil).ReportParticipant), arg0, arg1, arg2)
+}
+
+// SendMessage mocks base method.
+func (m *MockConversationServiceI) SendMessage(arg0 uuid.UUID, arg1 string, arg2 *models.MessageSendJSON) (models.Message, models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Message)
+	ret1, _ := ret[1].(models.Conversation)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockConversationServiceIMockRecorder) SendMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockConversationServiceI)(nil).SendMessage), arg0, arg1, arg2)
+}
+
+// StartConversation mocks base method.
+func (m *MockConversationServiceI) StartConversation(arg0 uuid.UUID, arg1 string) (models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartConversation", arg0, arg1)
+	ret0, _ := ret[0].(models.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartConversation indicates an expected call of StartConversation.
+func (mr *MockConversationServiceIMockRecorder) StartConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartConversation", reflect.TypeOf((*MockConversationServiceI)(nil).StartConversation), arg0, arg1)
+}
@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/conversations (interfaces: ConversationStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockConversationStoreI is a mock of ConversationStoreI interface.
+type MockConversationStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationStoreIMockRecorder
+}
+
+// MockConversationStoreIMockRecorder is the mock recorder for MockConversationStoreI.
+type MockConversationStoreIMockRecorder struct {
+	mock *MockConversationStoreI
+}
+
+// NewMockConversationStoreI creates a new mock instance.
+func NewMockConversationStoreI(ctrl *gomock.Controller) *MockConversationStoreI {
+	mock := &MockConversationStoreI{ctrl: ctrl}
+	mock.recorder = &MockConversationStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationStoreI) EXPECT() *MockConversationStoreIMockRecorder {
+	return m.recorder
+}
+
+// AddMessage mocks base method.
+func (m *MockConversationStoreI) AddMessage(arg0 *models.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessage", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMessage indicates an expected call of AddMessage.
+func (mr *MockConversationStoreIMockRecorder) AddMessage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessage", reflect.TypeOf((*MockConversationStoreI)(nil).AddMessage), arg0)
+}
+
+// AddReport mocks base method.
+func (m *MockConversationStoreI) AddReport(arg0 *models.Report) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReport", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReport indicates an expected call of AddReport.
+func (mr *MockConversationStoreIMockRecorder) AddReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReport", reflect.TypeOf((*MockConversationStoreI)(nil).AddReport), arg0)
+}
+
+// Block mocks base method.
+func (m *MockConversationStoreI) Block(arg0, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockConversationStoreIMockRecorder) Block(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockConversationStoreI)(nil).Block), arg0, arg1)
+}
+
+// CountConversationsSince mocks base method.
+func (m *MockConversationStoreI) CountConversationsSince(arg0 uuid.UUID, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountConversationsSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountConversationsSince indicates an expected call of CountConversationsSince.
+func (mr *MockConversationStoreIMockRecorder) CountConversationsSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountConversationsSince", reflect.TypeOf((*MockConversationStoreI)(nil).CountConversationsSince), arg0, arg1)
+}
+
+// CountMessagesSince mocks base method.
+func (m *MockConversationStoreI) CountMessagesSince(arg0 uuid.UUID, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMessagesSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMessagesSince indicates an expected call of CountMessagesSince.
+func (mr *MockConversationStoreIMockRecorder) CountMessagesSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMessagesSince", reflect.TypeOf((*MockConversationStoreI)(nil).CountMessagesSince), arg0, arg1)
+}
+
+// CreateConversation mocks base method.
+func (m *MockConversationStoreI) CreateConversation(arg0 *models.Conversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConversation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConversation indicates an expected call of CreateConversation.
+func (mr *MockConversationStoreIMockRecorder) CreateConversation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConversation", reflect.TypeOf((*MockConversationStoreI)(nil).CreateConversation), arg0)
+}
+
+// FindConversation mocks base method.
+func (m *MockConversationStoreI) FindConversation(arg0 uint, arg1 uuid.UUID) (models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindConversation", arg0, arg1)
+	ret0, _ := ret[0].(models.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindConversation indicates an expected call of FindConversation.
+func (mr *MockConversationStoreIMockRecorder) FindConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConversation", reflect.TypeOf((*MockConversationStoreI)(nil).FindConversation), arg0, arg1)
+}
+
+// GetConversation mocks base method.
+func (m *MockConversationStoreI) GetConversation(arg0 uint) (models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", arg0)
+	ret0, _ := ret[0].(models.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockConversationStoreIMockRecorder) GetConversation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockConversationStoreI)(nil).GetConversation), arg0)
+}
+
+// GetConversations mocks base method.
+func (m *MockConversationStoreI) GetConversations(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversations", arg0, arg1)
+	ret0, _ := ret[0].([]models.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversations indicates an expected call of GetConversations.
+func (mr *MockConversationStoreIMockRecorder) GetConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversations", reflect.TypeOf((*MockConversationStoreI)(nil).GetConversations), arg0, arg1)
+}
+
+// GetMessages mocks base method.
+func (m *MockConversationStoreI) GetMessages(arg0 uint, arg1 *gin.Context) ([]models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", arg0, arg1)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockConversationStoreIMockRecorder) GetMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockConversationStoreI)(nil).GetMessages), arg0, arg1)
+}
+
+// IsBlocked mocks base method.
+func (m *MockConversationStoreI) IsBlocked(arg0, arg1 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockConversationStoreIMockRecorder) IsBlocked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockConversationStoreI)(nil).IsBlocked), arg0, arg1)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockConversationStoreI) MarkAsRead(arg0 models.Conversation, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockConversationStoreIMockRecorder) MarkAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockConversationStoreI)(nil).MarkAsRead), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/favorites (interfaces: FavoriteStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFavoriteStoreI is a mock of FavoriteStoreI interface.
+type MockFavoriteStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteStoreIMockRecorder
+}
+
+// MockFavoriteStoreIMockRecorder is the mock recorder for MockFavoriteStoreI.
+type MockFavoriteStoreIMockRecorder struct {
+	mock *MockFavoriteStoreI
+}
+
+// NewMockFavoriteStoreI creates a new mock instance.
+func NewMockFavoriteStoreI(ctrl *gomock.Controller) *MockFavoriteStoreI {
+	mock := &MockFavoriteStoreI{ctrl: ctrl}
+	mock.recorder = &MockFavoriteStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteStoreI) EXPECT() *MockFavoriteStoreIMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockFavoriteStoreI) AddFavorite(arg0 *models.Favorite) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockFavoriteStoreIMockRecorder) AddFavorite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockFavoriteStoreI)(nil).AddFavorite), arg0)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockFavoriteStoreI) RemoveFavorite(arg0 uuid.UUID, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockFavoriteStoreIMockRecorder) RemoveFavorite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockFavoriteStoreI)(nil).RemoveFavorite), arg0, arg1)
+}
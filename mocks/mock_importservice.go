@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: ImportServiceI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+
+	services "github.com/Kachyr/findyourpet/findyourpet-backend/internal/services"
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockImportServiceI is a mock of ImportServiceI interface.
+type MockImportServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceIMockRecorder
+}
+
+// MockImportServiceIMockRecorder is the mock recorder for MockImportServiceI.
+type MockImportServiceIMockRecorder struct {
+	mock *MockImportServiceI
+}
+
+// NewMockImportServiceI creates a new mock instance.
+func NewMockImportServiceI(ctrl *gomock.Controller) *MockImportServiceI {
+	mock := &MockImportServiceI{ctrl: ctrl}
+	mock.recorder = &MockImportServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportServiceI) EXPECT() *MockImportServiceIMockRecorder {
+	return m.recorder
+}
+
+// ImportAnimals mocks base method.
+func (m *MockImportServiceI) ImportAnimals(arg0 uuid.UUID, arg1 io.Reader, arg2 services.ImportOptions) (models.ImportReportJSON, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportAnimals", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.ImportReportJSON)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportAnimals indicates an expected call of ImportAnimals.
+func (mr *MockImportServiceIMockRecorder) ImportAnimals(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAnimals", reflect.TypeOf((*MockImportServiceI)(nil).ImportAnimals), arg0, arg1, arg2)
+}
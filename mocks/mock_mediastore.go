@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/media (interfaces: MediaStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaStoreI is a mock of MediaStoreI interface.
+type MockMediaStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaStoreIMockRecorder
+}
+
+// MockMediaStoreIMockRecorder is the mock recorder for MockMediaStoreI.
+type MockMediaStoreIMockRecorder struct {
+	mock *MockMediaStoreI
+}
+
+// NewMockMediaStoreI creates a new mock instance.
+func NewMockMediaStoreI(ctrl *gomock.Controller) *MockMediaStoreI {
+	mock := &MockMediaStoreI{ctrl: ctrl}
+	mock.recorder = &MockMediaStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaStoreI) EXPECT() *MockMediaStoreIMockRecorder {
+	return m.recorder
+}
+
+// ReferencedKeys mocks base method.
+func (m *MockMediaStoreI) ReferencedKeys() (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReferencedKeys")
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReferencedKeys indicates an expected call of ReferencedKeys.
+func (mr *MockMediaStoreIMockRecorder) ReferencedKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReferencedKeys", reflect.TypeOf((*MockMediaStoreI)(nil).ReferencedKeys))
+}
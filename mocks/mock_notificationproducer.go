@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: NotificationProducerI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationProducerI is a mock of NotificationProducerI interface.
+type MockNotificationProducerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationProducerIMockRecorder
+}
+
+// MockNotificationProducerIMockRecorder is the mock recorder for MockNotificationProducerI.
+type MockNotificationProducerIMockRecorder struct {
+	mock *MockNotificationProducerI
+}
+
+// NewMockNotificationProducerI creates a new mock instance.
+func NewMockNotificationProducerI(ctrl *gomock.Controller) *MockNotificationProducerI {
+	mock := &MockNotificationProducerI{ctrl: ctrl}
+	mock.recorder = &MockNotificationProducerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationProducerI) EXPECT() *MockNotificationProducerIMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotificationProducerI) Notify(arg0 models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationProducerIMockRecorder) Notify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationProducerI)(nil).Notify), arg0)
+}
+
+// NotifyUsers mocks base method.
+func (m *MockNotificationProducerI) NotifyUsers(arg0 []uuid.UUID, arg1 models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyUsers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyUsers indicates an expected call of NotifyUsers.
+func (mr *MockNotificationProducerIMockRecorder) NotifyUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyUsers", reflect.TypeOf((*MockNotificationProducerI)(nil).NotifyUsers), arg0, arg1)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: NotificationServiceI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationServiceI is a mock of NotificationServiceI interface.
+type MockNotificationServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceIMockRecorder
+}
+
+// MockNotificationServiceIMockRecorder is the mock recorder for MockNotificationServiceI.
+type MockNotificationServiceIMockRecorder struct {
+	mock *MockNotificationServiceI
+}
+
+// NewMockNotificationServiceI creates a new mock instance.
+func NewMockNotificationServiceI(ctrl *gomock.Controller) *MockNotificationServiceI {
+	mock := &MockNotificationServiceI{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationServiceI) EXPECT() *MockNotificationServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationServiceI) GetNotifications(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Notification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationServiceIMockRecorder) GetNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationServiceI)(nil).GetNotifications), arg0, arg1)
+}
+
+// MarkAllAsRead mocks base method.
+func (m *MockNotificationServiceI) MarkAllAsRead(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllAsRead", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllAsRead indicates an expected call of MarkAllAsRead.
+func (mr *MockNotificationServiceIMockRecorder) MarkAllAsRead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllAsRead", reflect.TypeOf((*MockNotificationServiceI)(nil).MarkAllAsRead), arg0)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockNotificationServiceI) MarkAsRead(arg0 uuid.UUID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockNotificationServiceIMockRecorder) MarkAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockNotificationServiceI)(nil).MarkAsRead), arg0, arg1)
+}
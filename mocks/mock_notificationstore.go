@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/notifications (interfaces: NotificationStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationStoreI is a mock of NotificationStoreI interface.
+type MockNotificationStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreIMockRecorder
+}
+
+// MockNotificationStoreIMockRecorder is the mock recorder for MockNotificationStoreI.
+type MockNotificationStoreIMockRecorder struct {
+	mock *MockNotificationStoreI
+}
+
+// NewMockNotificationStoreI creates a new mock instance.
+func NewMockNotificationStoreI(ctrl *gomock.Controller) *MockNotificationStoreI {
+	mock := &MockNotificationStoreI{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStoreI) EXPECT() *MockNotificationStoreIMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationStoreI) CountUnread(arg0 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationStoreIMockRecorder) CountUnread(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationStoreI)(nil).CountUnread), arg0)
+}
+
+// CreateNotifications mocks base method.
+func (m *MockNotificationStoreI) CreateNotifications(arg0 []models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockNotificationStoreIMockRecorder) CreateNotifications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockNotificationStoreI)(nil).CreateNotifications), arg0)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationStoreI) GetNotifications(arg0 uuid.UUID, arg1 *gin.Context) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationStoreIMockRecorder) GetNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationStoreI)(nil).GetNotifications), arg0, arg1)
+}
+
+// MarkAllAsRead mocks base method.
+func (m *MockNotificationStoreI) MarkAllAsRead(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllAsRead", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllAsRead indicates an expected call of MarkAllAsRead.
+func (mr *MockNotificationStoreIMockRecorder) MarkAllAsRead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllAsRead", reflect.TypeOf((*MockNotificationStoreI)(nil).MarkAllAsRead), arg0)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockNotificationStoreI) MarkAsRead(arg0 uuid.UUID, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockNotificationStoreIMockRecorder) MarkAsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockNotificationStoreI)(nil).MarkAsRead), arg0, arg1)
+}
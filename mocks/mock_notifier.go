@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: NotifierI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotifierI is a mock of NotifierI interface.
+type MockNotifierI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierIMockRecorder
+}
+
+// MockNotifierIMockRecorder is the mock recorder for MockNotifierI.
+type MockNotifierIMockRecorder struct {
+	mock *MockNotifierI
+}
+
+// NewMockNotifierI creates a new mock instance.
+func NewMockNotifierI(ctrl *gomock.Controller) *MockNotifierI {
+	mock := &MockNotifierI{ctrl: ctrl}
+	mock.recorder = &MockNotifierIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifierI) EXPECT() *MockNotifierIMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifierI) Notify(arg0 models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierIMockRecorder) Notify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifierI)(nil).Notify), arg0)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/outbox (interfaces: OutboxStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxStoreI is a mock of OutboxStoreI interface.
+type MockOutboxStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxStoreIMockRecorder
+}
+
+// MockOutboxStoreIMockRecorder is the mock recorder for MockOutboxStoreI.
+type MockOutboxStoreIMockRecorder struct {
+	mock *MockOutboxStoreI
+}
+
+// NewMockOutboxStoreI creates a new mock instance.
+func NewMockOutboxStoreI(ctrl *gomock.Controller) *MockOutboxStoreI {
+	mock := &MockOutboxStoreI{ctrl: ctrl}
+	mock.recorder = &MockOutboxStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxStoreI) EXPECT() *MockOutboxStoreIMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueEmails mocks base method.
+func (m *MockOutboxStoreI) ClaimDueEmails(arg0 time.Time, arg1 int, arg2 time.Duration) ([]models.OutboxEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueEmails", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.OutboxEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueEmails indicates an expected call of ClaimDueEmails.
+func (mr *MockOutboxStoreIMockRecorder) ClaimDueEmails(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueEmails", reflect.TypeOf((*MockOutboxStoreI)(nil).ClaimDueEmails), arg0, arg1, arg2)
+}
+
+// Enqueue mocks base method.
+func (m *MockOutboxStoreI) Enqueue(arg0 ...models.OutboxEmail) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Enqueue", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockOutboxStoreIMockRecorder) Enqueue(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockOutboxStoreI)(nil).Enqueue), arg0...)
+}
+
+// IsSuppressed mocks base method.
+func (m *MockOutboxStoreI) IsSuppressed(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSuppressed", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSuppressed indicates an expected call of IsSuppressed.
+func (mr *MockOutboxStoreIMockRecorder) IsSuppressed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSuppressed", reflect.TypeOf((*MockOutboxStoreI)(nil).IsSuppressed), arg0)
+}
+
+// Suppress mocks base method.
+func (m *MockOutboxStoreI) Suppress(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suppress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suppress indicates an expected call of Suppress.
+func (mr *MockOutboxStoreIMockRecorder) Suppress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suppress", reflect.TypeOf((*MockOutboxStoreI)(nil).Suppress), arg0, arg1)
+}
+
+// UpdateEmail mocks base method.
+func (m *MockOutboxStoreI) UpdateEmail(arg0 *models.OutboxEmail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockOutboxStoreIMockRecorder) UpdateEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockOutboxStoreI)(nil).UpdateEmail), arg0)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos (interfaces: PhotoServiceI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	storage "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPhotoServiceI is a mock of PhotoServiceI interface.
+type MockPhotoServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhotoServiceIMockRecorder
+}
+
+// MockPhotoServiceIMockRecorder is the mock recorder for MockPhotoServiceI.
+type MockPhotoServiceIMockRecorder struct {
+	mock *MockPhotoServiceI
+}
+
+// NewMockPhotoServiceI creates a new mock instance.
+func NewMockPhotoServiceI(ctrl *gomock.Controller) *MockPhotoServiceI {
+	mock := &MockPhotoServiceI{ctrl: ctrl}
+	mock.recorder = &MockPhotoServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhotoServiceI) EXPECT() *MockPhotoServiceIMockRecorder {
+	return m.recorder
+}
+
+// DeletePhoto mocks base method.
+func (m *MockPhotoServiceI) DeletePhoto(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockPhotoServiceIMockRecorder) DeletePhoto(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockPhotoServiceI)(nil).DeletePhoto), arg0)
+}
+
+// GetPhotoInfo mocks base method.
+func (m *MockPhotoServiceI) GetPhotoInfo(arg0 string) (storage.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhotoInfo", arg0)
+	ret0, _ := ret[0].(storage.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhotoInfo indicates an expected call of GetPhotoInfo.
+func (mr *MockPhotoServiceIMockRecorder) GetPhotoInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhotoInfo", reflect.TypeOf((*MockPhotoServiceI)(nil).GetPhotoInfo), arg0)
+}
+
+// PresignPhotoUpload mocks base method.
+func (m *MockPhotoServiceI) PresignPhotoUpload(arg0, arg1 string, arg2 int64, arg3 time.Duration) (models.PresignedUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignPhotoUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.PresignedUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignPhotoUpload indicates an expected call of PresignPhotoUpload.
+func (mr *MockPhotoServiceIMockRecorder) PresignPhotoUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignPhotoUpload", reflect.TypeOf((*MockPhotoServiceI)(nil).PresignPhotoUpload), arg0, arg1, arg2, arg3)
+}
+
+// ProcessUploadedPhoto mocks base method.
+func (m *MockPhotoServiceI) ProcessUploadedPhoto(arg0, arg1 string) (models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessUploadedPhoto", arg0, arg1)
+	ret0, _ := ret[0].(models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessUploadedPhoto indicates an expected call of ProcessUploadedPhoto.
+func (mr *MockPhotoServiceIMockRecorder) ProcessUploadedPhoto(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessUploadedPhoto", reflect.TypeOf((*MockPhotoServiceI)(nil).ProcessUploadedPhoto), arg0, arg1)
+}
+
+// UploadPhotoFromReader mocks base method.
+func (m *MockPhotoServiceI) UploadPhotoFromReader(arg0 io.Reader, arg1 string) (models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPhotoFromReader", arg0, arg1)
+	ret0, _ := ret[0].(models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPhotoFromReader indicates an expected call of UploadPhotoFromReader.
+func (mr *MockPhotoServiceIMockRecorder) UploadPhotoFromReader(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPhotoFromReader", reflect.TypeOf((*MockPhotoServiceI)(nil).UploadPhotoFromReader), arg0, arg1)
+}
+
+// UploadSinglePhoto mocks base method.
+func (m *MockPhotoServiceI) UploadSinglePhoto(arg0, arg1 string) (models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadSinglePhoto", arg0, arg1)
+	ret0, _ := ret[0].(models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadSinglePhoto indicates an expected call of UploadSinglePhoto.
+func (mr *MockPhotoServiceIMockRecorder) UploadSinglePhoto(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSinglePhoto", reflect.TypeOf((*MockPhotoServiceI)(nil).UploadSinglePhoto), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events (interfaces: PublisherI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	events "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPublisherI is a mock of PublisherI interface.
+type MockPublisherI struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherIMockRecorder
+}
+
+// MockPublisherIMockRecorder is the mock recorder for MockPublisherI.
+type MockPublisherIMockRecorder struct {
+	mock *MockPublisherI
+}
+
+// NewMockPublisherI creates a new mock instance.
+func NewMockPublisherI(ctrl *gomock.Controller) *MockPublisherI {
+	mock := &MockPublisherI{ctrl: ctrl}
+	mock.recorder = &MockPublisherIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherI) EXPECT() *MockPublisherIMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisherI) Publish(arg0 events.Topic, arg1 []uuid.UUID, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherIMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisherI)(nil).Publish), arg0, arg1, arg2)
+}
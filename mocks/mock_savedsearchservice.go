@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: SavedSearchServiceI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSavedSearchServiceI is a mock of SavedSearchServiceI interface.
+type MockSavedSearchServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedSearchServiceIMockRecorder
+}
+
+// MockSavedSearchServiceIMockRecorder is the mock recorder for MockSavedSearchServiceI.
+type MockSavedSearchServiceIMockRecorder struct {
+	mock *MockSavedSearchServiceI
+}
+
+// NewMockSavedSearchServiceI creates a new mock instance.
+func NewMockSavedSearchServiceI(ctrl *gomock.Controller) *MockSavedSearchServiceI {
+	mock := &MockSavedSearchServiceI{ctrl: ctrl}
+	mock.recorder = &MockSavedSearchServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedSearchServiceI) EXPECT() *MockSavedSearchServiceIMockRecorder {
+	return m.recorder
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockSavedSearchServiceI) CreateSavedSearch(arg0 uuid.UUID, arg1 *models.SavedSearchJSON) (models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockSavedSearchServiceIMockRecorder) CreateSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockSavedSearchServiceI)(nil).CreateSavedSearch), arg0, arg1)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockSavedSearchServiceI) DeleteSavedSearch(arg0 uuid.UUID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockSavedSearchServiceIMockRecorder) DeleteSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockSavedSearchServiceI)(nil).DeleteSavedSearch), arg0, arg1)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockSavedSearchServiceI) GetSavedSearches(arg0 uuid.UUID) ([]models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", arg0)
+	ret0, _ := ret[0].([]models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockSavedSearchServiceIMockRecorder) GetSavedSearches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockSavedSearchServiceI)(nil).GetSavedSearches), arg0)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockSavedSearchServiceI) Unsubscribe(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockSavedSearchServiceIMockRecorder) Unsubscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockSavedSearchServiceI)(nil).Unsubscribe), arg0)
+}
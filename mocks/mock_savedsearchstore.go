@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/searches (interfaces: SavedSearchStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSavedSearchStoreI is a mock of SavedSearchStoreI interface.
+type MockSavedSearchStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedSearchStoreIMockRecorder
+}
+
+// MockSavedSearchStoreIMockRecorder is the mock recorder for MockSavedSearchStoreI.
+type MockSavedSearchStoreIMockRecorder struct {
+	mock *MockSavedSearchStoreI
+}
+
+// NewMockSavedSearchStoreI creates a new mock instance.
+func NewMockSavedSearchStoreI(ctrl *gomock.Controller) *MockSavedSearchStoreI {
+	mock := &MockSavedSearchStoreI{ctrl: ctrl}
+	mock.recorder = &MockSavedSearchStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedSearchStoreI) EXPECT() *MockSavedSearchStoreIMockRecorder {
+	return m.recorder
+}
+
+// ClaimRun mocks base method.
+func (m *MockSavedSearchStoreI) ClaimRun(arg0 *models.SavedSearch, arg1 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRun", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRun indicates an expected call of ClaimRun.
+func (mr *MockSavedSearchStoreIMockRecorder) ClaimRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRun", reflect.TypeOf((*MockSavedSearchStoreI)(nil).ClaimRun), arg0, arg1)
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockSavedSearchStoreI) CreateSavedSearch(arg0 *models.SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockSavedSearchStoreIMockRecorder) CreateSavedSearch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockSavedSearchStoreI)(nil).CreateSavedSearch), arg0)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockSavedSearchStoreI) DeleteSavedSearch(arg0 uuid.UUID, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockSavedSearchStoreIMockRecorder) DeleteSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockSavedSearchStoreI)(nil).DeleteSavedSearch), arg0, arg1)
+}
+
+// GetDueSavedSearches mocks base method.
+func (m *MockSavedSearchStoreI) GetDueSavedSearches(arg0 time.Time, arg1 *models.SavedSearch, arg2 int) ([]models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueSavedSearches", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueSavedSearches indicates an expected call of GetDueSavedSearches.
+func (mr *MockSavedSearchStoreIMockRecorder) GetDueSavedSearches(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueSavedSearches", reflect.TypeOf((*MockSavedSearchStoreI)(nil).GetDueSavedSearches), arg0, arg1, arg2)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockSavedSearchStoreI) GetSavedSearches(arg0 uuid.UUID) ([]models.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", arg0)
+	ret0, _ := ret[0].([]models.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockSavedSearchStoreIMockRecorder) GetSavedSearches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockSavedSearchStoreI)(nil).GetSavedSearches), arg0)
+}
+
+// ReleaseRun mocks base method.
+func (m *MockSavedSearchStoreI) ReleaseRun(arg0 *models.SavedSearch, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseRun indicates an expected call of ReleaseRun.
+func (mr *MockSavedSearchStoreIMockRecorder) ReleaseRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRun", reflect.TypeOf((*MockSavedSearchStoreI)(nil).ReleaseRun), arg0, arg1)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockSavedSearchStoreI) Unsubscribe(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockSavedSearchStoreIMockRecorder) Unsubscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockSavedSearchStoreI)(nil).Unsubscribe), arg0)
+}
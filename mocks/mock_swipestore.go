@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/store/swipes (interfaces: SwipeStoreI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockSwipeStoreI is a mock of SwipeStoreI interface.
+type MockSwipeStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSwipeStoreIMockRecorder
+}
+
+// MockSwipeStoreIMockRecorder is the mock recorder for MockSwipeStoreI.
+type MockSwipeStoreIMockRecorder struct {
+	mock *MockSwipeStoreI
+}
+
+// NewMockSwipeStoreI creates a new mock instance.
+func NewMockSwipeStoreI(ctrl *gomock.Controller) *MockSwipeStoreI {
+	mock := &MockSwipeStoreI{ctrl: ctrl}
+	mock.recorder = &MockSwipeStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSwipeStoreI) EXPECT() *MockSwipeStoreIMockRecorder {
+	return m.recorder
+}
+
+// GetSwipeHistory mocks base method.
+func (m *MockSwipeStoreI) GetSwipeHistory(arg0 uuid.UUID, arg1 *gin.Context) ([]models.SwipeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwipeHistory", arg0, arg1)
+	ret0, _ := ret[0].([]models.SwipeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwipeHistory indicates an expected call of GetSwipeHistory.
+func (mr *MockSwipeStoreIMockRecorder) GetSwipeHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwipeHistory", reflect.TypeOf((*MockSwipeStoreI)(nil).GetSwipeHistory), arg0, arg1)
+}
+
+// UndoLastSwipe mocks base method.
+func (m *MockSwipeStoreI) UndoLastSwipe(arg0 uuid.UUID, arg1 time.Duration, arg2 string) (models.SwipeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoLastSwipe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.SwipeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoLastSwipe indicates an expected call of UndoLastSwipe.
+func (mr *MockSwipeStoreIMockRecorder) UndoLastSwipe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoLastSwipe", reflect.TypeOf((*MockSwipeStoreI)(nil).UndoLastSwipe), arg0, arg1, arg2)
+}
@@ -36,17 +36,22 @@ func (m *MockUserStoreI) EXPECT() *MockUserStoreIMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockUserStoreI) Create(arg0 *models.User) error {
+func (m *MockUserStoreI) Create(arg0 *models.User, arg1 ...models.OutboxEmail) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserStoreIMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockUserStoreIMockRecorder) Create(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserStoreI)(nil).Create), arg0)
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserStoreI)(nil).Create), varargs...)
 }
 
 // GetByEmail mocks base method.
@@ -107,3 +112,17 @@ func (mr *MockUserStoreIMockRecorder) SetUserSettings(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSettings", reflect.TypeOf((*MockUserStoreI)(nil).SetUserSettings), arg0, arg1)
 }
+
+// UpdateUser mocks base method.
+func (m *MockUserStoreI) UpdateUser(arg0 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserStoreIMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserStoreI)(nil).UpdateUser), arg0)
+}
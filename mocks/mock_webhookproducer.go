@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Kachyr/findyourpet/findyourpet-backend/internal/services (interfaces: WebhookProducerI)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockWebhookProducerI is a mock of WebhookProducerI interface.
+type MockWebhookProducerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookProducerIMockRecorder
+}
+
+// MockWebhookProducerIMockRecorder is the mock recorder for MockWebhookProducerI.
+type MockWebhookProducerIMockRecorder struct {
+	mock *MockWebhookProducerI
+}
+
+// NewMockWebhookProducerI creates a new mock instance.
+func NewMockWebhookProducerI(ctrl *gomock.Controller) *MockWebhookProducerI {
+	mock := &MockWebhookProducerI{ctrl: ctrl}
+	mock.recorder = &MockWebhookProducerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookProducerI) EXPECT() *MockWebhookProducerIMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhookProducerI) Enqueue(arg0 uuid.UUID, arg1 string, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookProducerIMockRecorder) Enqueue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhookProducerI)(nil).Enqueue), arg0, arg1, arg2)
+}
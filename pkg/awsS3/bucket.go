@@ -1,20 +1,20 @@
 package awsS3
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/google/uuid"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/spf13/viper"
 )
 
@@ -22,100 +22,175 @@ const (
 	awsRegionEnv = "AWS_REGION"
 	awsKeyEnv    = "AWS_ACCESS_KEY_ID"
 	awsSecretEnv = "AWS_SECRET_ACCESS_KEY"
-	maxImageSize = 5 * 1024 * 1024 // 5 MB
 )
 
-type S3ServiceI interface {
-	UploadPhotos(photos []string, photoNamesPrefix string) ([]models.Photo, error)
-	UploadSinglePhoto(photoURI string, prefix string) (*manager.UploadOutput, error)
+// S3Store keeps objects in an S3 bucket.
+type S3Store struct {
+	client    *s3.Client
+	uploader  *manager.Uploader
+	presigner *s3.PresignClient
+	bucket    string
+	// baseURL is where the bucket's objects are publicly read from.
+	baseURL string
 }
 
-type S3Service struct {
-	uploader *manager.Uploader
-	bucket   string
-}
-
-func NewS3Service(bucket string) *S3Service {
+// NewS3Store connects to AWS, or to an S3 compatible store such as MinIO
+// when the endpoint is set. Objects of custom endpoints are addressed by
+// path rather than by bucket subdomain.
+func NewS3Store(bucket, endpoint string) *S3Store {
+	region := viper.GetString(awsRegionEnv)
 	client := s3.New(s3.Options{
-		Region: viper.GetString(awsRegionEnv),
+		Region: region,
 		Credentials: aws.NewCredentialsCache(
 			credentials.NewStaticCredentialsProvider(
 				viper.GetString(awsKeyEnv),
 				viper.GetString(awsSecretEnv), "",
 			)),
+	}, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
 	})
 
-	return &S3Service{
-		uploader: manager.NewUploader(client),
-		bucket:   bucket,
+	baseURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	if endpoint != "" {
+		baseURL = strings.TrimSuffix(endpoint, "/") + "/" + bucket
+	}
+	return &S3Store{
+		client:    client,
+		uploader:  manager.NewUploader(client),
+		presigner: s3.NewPresignClient(client),
+		bucket:    bucket,
+		baseURL:   baseURL,
 	}
 }
 
-// UploadSinglePhoto uploads a single photo to S3.
-func (s *S3Service) UploadSinglePhoto(photoURI string, prefix string) (*manager.UploadOutput, error) {
-	valid, decodedData, err := s.validateAndDecodeBase64(photoURI)
-	if err != nil || !valid {
-		return nil, err
+func (s *S3Store) Put(key string, body io.Reader, contentType string) (storage.Object, error) {
+	counter := &countingReader{r: body}
+	_, err := s.uploader.Upload(context.TODO(), &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        counter,
+		ContentType: aws.String(contentType),
+		ACL:         "public-read",
+	})
+	if err != nil {
+		return storage.Object{}, err
 	}
+	return storage.Object{Key: key, URL: s.objectURL(key), Size: counter.n, ContentType: contentType}, nil
+}
 
-	result, err := s.uploadImage(bytes.NewReader(decodedData), s.createFilename(prefix))
+func (s *S3Store) Get(key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
 	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, storage.ErrNotFound
+		}
 		return nil, err
 	}
-
-	return result, nil
+	return object.Body, nil
 }
 
-// UploadPhotos uploads multiple photos to S3.
-func (s *S3Service) UploadPhotos(photos []string, photoNamesPrefix string) ([]models.Photo, error) {
-	photoUrls := make([]models.Photo, 0, len(photos))
-	for _, photoURI := range photos {
-		result, err := s.UploadSinglePhoto(photoURI, photoNamesPrefix)
-		if err != nil {
-			return nil, err
+func (s *S3Store) Head(key string) (storage.Object, error) {
+	head, err := s.client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return storage.Object{}, storage.ErrNotFound
 		}
-
-		photoUrls = append(photoUrls, models.Photo{ImageURL: result.Location, Key: *result.Key})
+		return storage.Object{}, err
 	}
-	return photoUrls, nil
+
+	return storage.Object{
+		Key:         key,
+		URL:         s.objectURL(key),
+		Size:        aws.ToInt64(head.ContentLength),
+		ContentType: aws.ToString(head.ContentType),
+		ModTime:     aws.ToTime(head.LastModified),
+	}, nil
 }
 
-func (s *S3Service) uploadImage(file io.Reader, filename string) (*manager.UploadOutput, error) {
-	result, err := s.uploader.Upload(context.TODO(), &s3.PutObjectInput{
+func (s *S3Store) Delete(key string) error {
+	_, err := s.client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucket),
-		Key:    aws.String(filename + ".jpg"),
-		Body:   file,
-		ACL:    "public-read",
+		Key:    aws.String(key),
 	})
-
-	return result, err
+	return err
 }
 
-func (s *S3Service) validateAndDecodeBase64(photoURI string) (bool, []byte, error) {
-	data, err := extractBase64Part(photoURI)
-	if err != nil {
-		return false, nil, err
+// List pages through the bucket, which returns keys in ascending order.
+func (s *S3Store) List(prefix string) ([]storage.Object, error) {
+	var objects []storage.Object
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			objects = append(objects, storage.Object{
+				Key:     key,
+				URL:     s.objectURL(key),
+				Size:    aws.ToInt64(object.Size),
+				ModTime: aws.ToTime(object.LastModified),
+			})
+		}
 	}
-	decodedData, err := base64.StdEncoding.DecodeString(data)
+	return objects, nil
+}
+
+// PresignPut signs a PUT of a publicly readable object of exactly that type
+// and size.
+func (s *S3Store) PresignPut(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error) {
+	req, err := s.presigner.PresignPutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+		ACL:           "public-read",
+	}, s3.WithPresignExpires(expires))
 	if err != nil {
-		return false, nil, err
+		return models.PresignedUpload{}, err
 	}
-	if len(decodedData) > maxImageSize {
-		return false, nil, errors.New("image is too large")
+
+	headers := make(map[string]string, len(req.SignedHeader))
+	for name := range req.SignedHeader {
+		if name != "Host" {
+			headers[name] = req.SignedHeader.Get(name)
+		}
 	}
-	return true, decodedData, nil
+	return models.PresignedUpload{
+		Key:       key,
+		Method:    req.Method,
+		URL:       req.URL,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
 }
 
-func extractBase64Part(dataURI string) (string, error) {
-	parts := strings.Split(dataURI, ",")
-	if len(parts) != 2 {
-		return "", errors.New("incorrect dataURI format")
-	}
-	return parts[1], nil
+func (s *S3Store) objectURL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
 }
 
-func (s *S3Service) createFilename(namePrefix string) string {
-	id := uuid.New()
-	result := fmt.Sprintf("%s_%s", namePrefix, id)
-	return result
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
@@ -0,0 +1,17 @@
+package constants
+
+import "time"
+
+const (
+	// EmailMaxAttempts is how many times an email is tried before it fails.
+	EmailMaxAttempts = 6
+	// EmailRetryBase is the delay before the first retry; it doubles up to EmailRetryMax.
+	EmailRetryBase = time.Minute
+	EmailRetryMax  = 6 * time.Hour
+	// EmailJobTick is how often the dispatcher looks for due emails.
+	EmailJobTick = 10 * time.Second
+	// EmailBatchSize is how many emails the dispatcher claims at once.
+	EmailBatchSize = 50
+	// EmailLease is how long a claimed email is hidden from other dispatchers.
+	EmailLease = 2 * time.Minute
+)
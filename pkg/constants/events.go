@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	// EventHistorySize is how many recent events are kept for reconnecting clients.
+	EventHistorySize = 1000
+	// StreamBufferSize is how many events may queue up for a single connection
+	// before it is dropped.
+	StreamBufferSize = 64
+	// StreamHeartbeatInterval keeps idle connections from being closed by proxies.
+	StreamHeartbeatInterval = 15 * time.Second
+	// StreamRetry is the reconnection delay suggested to SSE clients.
+	StreamRetry = 3 * time.Second
+
+	TopicsParam       = "topics"
+	LastEventIDParam  = "last_event_id"
+	LastEventIDHeader = "Last-Event-ID"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	// FeedCandidatePoolSize is how many unseen animals the feed ranking orders.
+	FeedCandidatePoolSize = 200
+	// FeedSignalHistorySize is how many recent swipes user preferences are learned from.
+	FeedSignalHistorySize = 500
+)
@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	// MessageRateLimit is how many messages a user may send per MessageRateWindow.
+	MessageRateLimit  = 20
+	MessageRateWindow = time.Minute
+	// ConversationRateLimit is how many conversations a user may start per ConversationRateWindow.
+	ConversationRateLimit  = 10
+	ConversationRateWindow = time.Hour
+)
@@ -1,8 +1,10 @@
 package constants
 
 const (
-	Page     = "page"
-	PageSize = "page_size"
+	Page      = "page"
+	PageSize  = "page_size"
+	Cursor    = "cursor"
+	WithTotal = "with_total"
 )
 
 const (
@@ -12,4 +14,10 @@ const (
 	LocationParam   = "location"
 	VaccinatedParam = "vaccinated"
 	SterilizedParam = "sterilized"
+	SearchParam     = "q"
+	SortParam       = "sort"
+	LatitudeParam   = "lat"
+	LongitudeParam  = "lng"
+	UnreadParam     = "unread"
+	AfterParam      = "after"
 )
@@ -0,0 +1,16 @@
+package constants
+
+import "time"
+
+const (
+	// SavedSearchDigestInterval is how often a saved search is run by default.
+	SavedSearchDigestInterval = 24 * time.Hour
+	// SavedSearchJobTick is how often the job looks for saved searches to run.
+	SavedSearchJobTick = 10 * time.Minute
+	// SavedSearchBatchSize is how many saved searches the job loads at once.
+	SavedSearchBatchSize = 100
+	// SavedSearchMatchLimit caps the new matches loaded for one digest.
+	SavedSearchMatchLimit = 50
+	// SavedSearchDigestSize is how many matches a digest lists by name.
+	SavedSearchDigestSize = 10
+)
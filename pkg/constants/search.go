@@ -0,0 +1,8 @@
+package constants
+
+// SearchLanguage is the PostgreSQL text search configuration used for
+// stemming animal names and descriptions.
+const SearchLanguage = "english"
+
+// SearchHighlightOptions configures ts_headline snippets returned with search results.
+const SearchHighlightOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
@@ -0,0 +1,10 @@
+package constants
+
+import "time"
+
+const (
+	// SwipeUndoWindow is how long after a swipe it can still be undone.
+	SwipeUndoWindow = 5 * time.Minute
+	// ClientHeader identifies the client app a swipe came from.
+	ClientHeader = "X-Client"
+)
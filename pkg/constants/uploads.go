@@ -0,0 +1,70 @@
+package constants
+
+import "time"
+
+const (
+	// Form fields of a multipart animal submission. The animal JSON has to
+	// come before the files.
+	AnimalFormField = "animal"
+	ImageFormField  = "image"
+	PhotosFormField = "photos"
+
+	// MaxPhotoSize limits every uploaded file.
+	MaxPhotoSize = 5 << 20
+	// MaxPhotos limits the files of a submission, including the main image.
+	MaxPhotos = 10
+	// MaxUploadSize limits the whole multipart request body.
+	MaxUploadSize = MaxPhotos*MaxPhotoSize + 1<<20
+)
+
+// DuplicateHashDistance is how many bits the perceptual hashes of two images
+// may differ in for them to count as the same picture, and
+// DuplicateCandidateLimit how many matching listings are flagged at most.
+const (
+	DuplicateHashDistance   = 6
+	DuplicateCandidateLimit = 5
+)
+
+// ModerationQueueSize is how many items moderators get at a time.
+const ModerationQueueSize = 50
+
+// PhotoUploadWorkers limits the photos of one request stored at a time.
+const PhotoUploadWorkers = 4
+
+// FilesPath is where the API serves files of the local storage backend.
+const FilesPath = "/files"
+
+// PresignedUploadExpiry is how long a presigned photo upload URL is valid.
+const PresignedUploadExpiry = 15 * time.Minute
+
+// Uploaded images must have both sides within these bounds, in pixels, and
+// at most MaxImagePixels in all, as they are decoded into memory.
+const (
+	MinImageSide   = 32
+	MaxImageSide   = 8192
+	MaxImagePixels = 24_000_000
+)
+
+// Uploaded images are stored in these sizes, by their longer side in pixels.
+const (
+	ThumbImageSide = 320
+	CardImageSide  = 800
+	FullImageSide  = 2048
+	// ImageQuality is the JPEG quality of the stored sizes.
+	ImageQuality = 85
+)
+
+// Bulk imports of listings.
+const (
+	// MaxImportSize limits the request body of an import.
+	MaxImportSize = 10 << 20
+	// MaxImportRows limits the rows of an import through the API.
+	MaxImportRows = 1000
+	// MaxImportFetches limits the photos an import through the API gives by
+	// URL, as they are downloaded while the request waits.
+	MaxImportFetches = 100
+	// ImportBatchSize is how many listings are inserted at a time.
+	ImportBatchSize = 50
+	// ImportFetchTimeout limits downloading a photo given by URL.
+	ImportFetchTimeout = 30 * time.Second
+)
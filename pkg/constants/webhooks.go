@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	// WebhookMaxAttempts is how many times a delivery is tried before it fails.
+	WebhookMaxAttempts = 8
+	// WebhookRetryBase is the delay before the first retry; it doubles up to WebhookRetryMax.
+	WebhookRetryBase = 30 * time.Second
+	WebhookRetryMax  = 6 * time.Hour
+	// WebhookTimeout bounds a single delivery request.
+	WebhookTimeout = 10 * time.Second
+	// WebhookJobTick is how often the worker looks for due deliveries.
+	WebhookJobTick = 5 * time.Second
+	// WebhookBatchSize is how many deliveries the worker claims at once.
+	WebhookBatchSize = 50
+	// WebhookLease is how long a claimed delivery is hidden from other workers.
+	WebhookLease = time.Minute
+)
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Topic string
+
+const (
+	// TopicAnimalCreated is published to everyone when an animal is posted.
+	TopicAnimalCreated Topic = "animal.created"
+	// TopicAnimalStatus is published to the users who liked an animal when its status changes.
+	TopicAnimalStatus Topic = "animal.status"
+	// TopicNotification is published to a user when a notification lands in their inbox.
+	TopicNotification Topic = "notification"
+	// TopicMessage is published to the recipient of a direct message.
+	TopicMessage Topic = "message"
+)
+
+// Topics are the topics clients may subscribe to.
+var Topics = []Topic{TopicAnimalCreated, TopicAnimalStatus, TopicNotification, TopicMessage}
+
+type Event struct {
+	ID    uint64          `json:"id"`
+	Topic Topic           `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+	// Users are the recipients of the event; an event without users is for everyone.
+	Users     []uuid.UUID `json:"-"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func (e Event) isFor(userID uuid.UUID) bool {
+	if len(e.Users) == 0 {
+		return true
+	}
+	for _, u := range e.Users {
+		if u == userID {
+			return true
+		}
+	}
+	return false
+}
+
+type PublisherI interface {
+	Publish(topic Topic, users []uuid.UUID, data interface{}) error
+}
+
+// Bus delivers events to the subscribers of this process and keeps the most
+// recent ones so that reconnecting clients can catch up. Event IDs start over
+// when the process restarts.
+type Bus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewBus(historySize int) *Bus {
+	return &Bus{
+		historySize: historySize,
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish sends the event to the users, or to everyone when users is empty.
+func (b *Bus) Publish(topic Topic, users []uuid.UUID, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Topic: topic, Data: payload, Users: users, CreatedAt: time.Now()}
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subscribers {
+		if !sub.wants(event) {
+			continue
+		}
+		select {
+		case sub.c <- event:
+		default:
+			// The client does not keep up. Dropping it lets it reconnect and
+			// replay from the last event it received.
+			b.remove(sub)
+		}
+	}
+	return nil
+}
+
+// Subscription receives the events of the subscribed topics for one user.
+type Subscription struct {
+	UserID uuid.UUID
+	topics map[Topic]bool
+	c      chan Event
+	// Replay holds the missed events after the requested last event ID.
+	Replay []Event
+	// Gap is set when some of the missed events are no longer kept, so the
+	// client has to refetch its state.
+	Gap bool
+	// LastID is the ID of the last event published before subscribing.
+	LastID uint64
+}
+
+// Events is closed when the subscription is dropped for falling behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.c
+}
+
+func (s *Subscription) wants(event Event) bool {
+	return (len(s.topics) == 0 || s.topics[event.Topic]) && event.isFor(s.UserID)
+}
+
+// Subscribe subscribes the user to the topics, or to every topic when none
+// are given. Events published after lastEventID are replayed, unless
+// lastEventID is zero. At most buffer events are queued for the subscriber.
+func (b *Bus) Subscribe(userID uuid.UUID, topics []Topic, lastEventID uint64, buffer int) *Subscription {
+	sub := &Subscription{UserID: userID, topics: map[Topic]bool{}, c: make(chan Event, buffer)}
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub.LastID = b.lastID
+	if lastEventID != 0 {
+		switch {
+		case lastEventID > b.lastID:
+			// The client saw events of a previous run of the process.
+			sub.Gap = true
+		case lastEventID < b.lastID && (len(b.history) == 0 || b.history[0].ID > lastEventID+1):
+			sub.Gap = true
+		}
+		for _, event := range b.history {
+			if event.ID > lastEventID && sub.wants(event) {
+				sub.Replay = append(sub.Replay, event)
+			}
+		}
+	}
+
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *Bus) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+// ParseTopics parses a list of topic names.
+func ParseTopics(names []string) ([]Topic, error) {
+	var topics []Topic
+	for _, name := range names {
+		topic, err := parseTopic(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+func parseTopic(name string) (Topic, error) {
+	for _, topic := range Topics {
+		if string(topic) == name {
+			return topic, nil
+		}
+	}
+	return "", fmt.Errorf("unknown topic %q", name)
+}
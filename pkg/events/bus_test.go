@@ -0,0 +1,84 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/events"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := events.NewBus(10)
+	alice, bob := uuid.New(), uuid.New()
+
+	t.Run("Events are filtered by topic and user", func(t *testing.T) {
+		sub := bus.Subscribe(alice, []events.Topic{events.TopicAnimalStatus}, 0, 10)
+		defer bus.Unsubscribe(sub)
+
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, "new"))
+		assert.NoError(t, bus.Publish(events.TopicAnimalStatus, []uuid.UUID{bob}, "bob's"))
+		assert.NoError(t, bus.Publish(events.TopicAnimalStatus, []uuid.UUID{alice, bob}, "ours"))
+
+		event := <-sub.Events()
+		assert.Equal(t, events.TopicAnimalStatus, event.Topic)
+		assert.JSONEq(t, `"ours"`, string(event.Data))
+		assert.Empty(t, sub.Events())
+	})
+
+	t.Run("Slow subscriber is dropped", func(t *testing.T) {
+		sub := bus.Subscribe(alice, nil, 0, 1)
+
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, 1))
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, 2))
+
+		_, ok := <-sub.Events()
+		assert.True(t, ok)
+		_, ok = <-sub.Events()
+		assert.False(t, ok)
+		bus.Unsubscribe(sub)
+	})
+}
+
+func TestBus_SubscribeReplay(t *testing.T) {
+	bus := events.NewBus(3)
+	user := uuid.New()
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, bus.Publish(events.TopicAnimalCreated, nil, i))
+	}
+
+	t.Run("Missed events are replayed", func(t *testing.T) {
+		sub := bus.Subscribe(user, nil, 3, 10)
+		defer bus.Unsubscribe(sub)
+
+		assert.False(t, sub.Gap)
+		if assert.Len(t, sub.Replay, 2) {
+			assert.Equal(t, uint64(4), sub.Replay[0].ID)
+			assert.Equal(t, uint64(5), sub.Replay[1].ID)
+		}
+	})
+
+	t.Run("Events no longer kept cause a gap", func(t *testing.T) {
+		sub := bus.Subscribe(user, nil, 1, 10)
+		defer bus.Unsubscribe(sub)
+
+		assert.True(t, sub.Gap)
+		assert.Equal(t, uint64(5), sub.LastID)
+	})
+
+	t.Run("Events of a previous process cause a gap", func(t *testing.T) {
+		sub := bus.Subscribe(user, nil, 42, 10)
+		defer bus.Unsubscribe(sub)
+
+		assert.True(t, sub.Gap)
+	})
+}
+
+func TestParseTopics(t *testing.T) {
+	topics, err := events.ParseTopics([]string{"animal.created", " message"})
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Topic{events.TopicAnimalCreated, events.TopicMessage}, topics)
+
+	_, err = events.ParseTopics([]string{"animals"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package feed
+
+import "github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+
+// IsMaterialChange reports whether an update to a listing is significant
+// enough to show it again to users who passed on it: new photos or cover, a
+// lower adoption fee or becoming available again. Reserving or adopting the
+// animal takes it out of the feed instead.
+func IsMaterialChange(before, after models.Animal) bool {
+	if before.Status != after.Status {
+		return after.Status == models.AnimalStatusAvailable
+	}
+	if after.Fee != nil && (before.Fee == nil || *after.Fee < *before.Fee) {
+		return true
+	}
+	if after.Fee == nil && before.Fee != nil && *before.Fee > 0 {
+		return true
+	}
+
+	if after.Image.Key != "" && after.Image.Key != before.Image.Key {
+		return true
+	}
+
+	keys := make(map[string]bool, len(before.Photos))
+	for _, p := range before.Photos {
+		keys[p.Key] = true
+	}
+	for _, p := range after.Photos {
+		if !keys[p.Key] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package feed_test
+
+import (
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/feed"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMaterialChange(t *testing.T) {
+	fee := func(v float64) *float64 { return &v }
+	base := models.Animal{
+		Status:      models.AnimalStatusAvailable,
+		Fee:         fee(100),
+		Description: "Calm",
+		Photos:      []models.Photo{{Key: "a.jpg"}},
+	}
+
+	tests := []struct {
+		name     string
+		update   func(a *models.Animal)
+		expected bool
+	}{
+		{name: "no change", update: func(a *models.Animal) {}, expected: false},
+		{name: "description change", update: func(a *models.Animal) { a.Description = "Very calm" }, expected: false},
+		{name: "reserved", update: func(a *models.Animal) { a.Status = models.AnimalStatusReserved }, expected: false},
+		{name: "adopted with a new photo", update: func(a *models.Animal) {
+			a.Status = models.AnimalStatusAdopted
+			a.Photos = []models.Photo{{Key: "a.jpg"}, {Key: "b.jpg"}}
+		}, expected: false},
+		{name: "fee drop", update: func(a *models.Animal) { a.Fee = fee(50) }, expected: true},
+		{name: "fee raise", update: func(a *models.Animal) { a.Fee = fee(150) }, expected: false},
+		{name: "fee removed", update: func(a *models.Animal) { a.Fee = nil }, expected: true},
+		{name: "new photo", update: func(a *models.Animal) {
+			a.Photos = []models.Photo{{Key: "a.jpg"}, {Key: "b.jpg"}}
+		}, expected: true},
+		{name: "photo removed", update: func(a *models.Animal) { a.Photos = nil }, expected: false},
+		{name: "new cover", update: func(a *models.Animal) { a.Image = models.Image{Key: "a.jpg"} }, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := base
+			after.Fee = fee(*base.Fee)
+			tt.update(&after)
+
+			assert.Equal(t, tt.expected, feed.IsMaterialChange(base, after))
+		})
+	}
+
+	t.Run("available again", func(t *testing.T) {
+		reserved := base
+		reserved.Status = models.AnimalStatusReserved
+		assert.True(t, feed.IsMaterialChange(reserved, base))
+	})
+}
@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// Forever hides a seen animal from the swipe feed permanently.
+const Forever time.Duration = -1
+
+// ReshowPolicy decides when an animal the user already swiped on appears in
+// the swipe feed again.
+type ReshowPolicy struct {
+	// PassedHideFor is how long animals the user passed on stay hidden.
+	PassedHideFor time.Duration
+	// LikedHideFor is how long liked animals stay hidden.
+	LikedHideFor time.Duration
+	// ResurfaceOnChange shows passed animals again before PassedHideFor
+	// elapses if their listing materially changed after the swipe.
+	ResurfaceOnChange bool
+}
+
+var DefaultReshowPolicy = ReshowPolicy{
+	PassedHideFor:     24 * time.Hour,
+	LikedHideFor:      Forever,
+	ResurfaceOnChange: true,
+}
+
+// Condition returns the filter over animals LEFT JOINed with the user's
+// seen_animals rows that keeps the animals the feed may show at the given time.
+func (p ReshowPolicy) Condition(now time.Time) clause.Expr {
+	conditions := []string{"seen_animals.seen_at IS NULL"}
+	var vars []interface{}
+
+	if p.LikedHideFor != Forever {
+		conditions = append(conditions, "(seen_animals.liked AND seen_animals.seen_at < ?)")
+		vars = append(vars, now.Add(-p.LikedHideFor))
+	}
+
+	var passed []string
+	if p.PassedHideFor != Forever {
+		passed = append(passed, "seen_animals.seen_at < ?")
+		vars = append(vars, now.Add(-p.PassedHideFor))
+	}
+	if p.ResurfaceOnChange {
+		passed = append(passed, "animals.listing_changed_at > seen_animals.seen_at")
+	}
+	if len(passed) > 0 {
+		conditions = append(conditions, fmt.Sprintf("(NOT seen_animals.liked AND (%s))", strings.Join(passed, " OR ")))
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(conditions, " OR ") + ")", Vars: vars}
+}
+
+// ParseHideDuration parses a hide period such as "24h" or "720h", or "forever".
+func ParseHideDuration(value string) (time.Duration, error) {
+	if strings.EqualFold(value, "forever") {
+		return Forever, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("hide period %s is negative", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,85 @@
+package feed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/feed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReshowPolicy_Condition(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		policy       feed.ReshowPolicy
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		{
+			name:   "default policy",
+			policy: feed.DefaultReshowPolicy,
+			expectedSQL: "(seen_animals.seen_at IS NULL OR (NOT seen_animals.liked AND " +
+				"(seen_animals.seen_at < ? OR animals.listing_changed_at > seen_animals.seen_at)))",
+			expectedVars: []interface{}{now.Add(-24 * time.Hour)},
+		},
+		{
+			name:         "everything hidden forever",
+			policy:       feed.ReshowPolicy{PassedHideFor: feed.Forever, LikedHideFor: feed.Forever},
+			expectedSQL:  "(seen_animals.seen_at IS NULL)",
+			expectedVars: nil,
+		},
+		{
+			name:   "passed hidden forever unless changed",
+			policy: feed.ReshowPolicy{PassedHideFor: feed.Forever, LikedHideFor: feed.Forever, ResurfaceOnChange: true},
+			expectedSQL: "(seen_animals.seen_at IS NULL OR (NOT seen_animals.liked AND " +
+				"(animals.listing_changed_at > seen_animals.seen_at)))",
+			expectedVars: nil,
+		},
+		{
+			name:   "liked shown again after a month",
+			policy: feed.ReshowPolicy{PassedHideFor: time.Hour, LikedHideFor: 720 * time.Hour},
+			expectedSQL: "(seen_animals.seen_at IS NULL OR (seen_animals.liked AND seen_animals.seen_at < ?) OR " +
+				"(NOT seen_animals.liked AND (seen_animals.seen_at < ?)))",
+			expectedVars: []interface{}{now.Add(-720 * time.Hour), now.Add(-time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.policy.Condition(now)
+
+			assert.Equal(t, tt.expectedSQL, condition.SQL)
+			assert.Equal(t, tt.expectedVars, condition.Vars)
+		})
+	}
+}
+
+func TestParseHideDuration(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{value: "24h", expected: 24 * time.Hour},
+		{value: "forever", expected: feed.Forever},
+		{value: "FOREVER", expected: feed.Forever},
+		{value: "0s", expected: 0},
+		{value: "-1h", expectedErr: true},
+		{value: "a week", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := feed.ParseHideDuration(tt.value)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Package imports reads listings in bulk, from CSV with a header row or
+// from JSON Lines in the API representation of an animal.
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+// Formats of an import.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrUnknownColumn = errors.New("unknown column")
+	ErrTooManyRows   = errors.New("too many rows")
+)
+
+// columns are the CSV columns, named like the JSON fields.
+var columns = map[string]func(a *models.AnimalJSON, value string) error{
+	"name":        func(a *models.AnimalJSON, v string) error { a.Name = v; return nil },
+	"type":        func(a *models.AnimalJSON, v string) error { a.Type = v; return nil },
+	"description": func(a *models.AnimalJSON, v string) error { a.Description = v; return nil },
+	"gender":      func(a *models.AnimalJSON, v string) error { a.Gender = strings.ToUpper(v); return nil },
+	"status":      func(a *models.AnimalJSON, v string) error { a.Status = strings.ToUpper(v); return nil },
+	"image":       func(a *models.AnimalJSON, v string) error { a.Image = v; return nil },
+	"photos":      func(a *models.AnimalJSON, v string) error { a.Photos = splitPhotos(v); return nil },
+	"age": func(a *models.AnimalJSON, v string) error {
+		age, err := strconv.ParseFloat(v, 32)
+		a.Age = float32(age)
+		return err
+	},
+	"vaccinated": func(a *models.AnimalJSON, v string) (err error) { a.Vaccinated, err = strconv.ParseBool(v); return },
+	"sterilized": func(a *models.AnimalJSON, v string) (err error) { a.Sterilized, err = strconv.ParseBool(v); return },
+	"latitude":   func(a *models.AnimalJSON, v string) (err error) { a.Latitude, err = parseOptional(v); return },
+	"longitude":  func(a *models.AnimalJSON, v string) (err error) { a.Longitude, err = parseOptional(v); return },
+	"fee":        func(a *models.AnimalJSON, v string) (err error) { a.Fee, err = parseOptional(v); return },
+}
+
+// Row is a listing read from the import. Rows that can not be read have an
+// error instead.
+type Row struct {
+	// Line is where the row starts, counting from 1 and including the
+	// header, as spreadsheets number them.
+	Line   int
+	Animal models.AnimalJSON
+	Err    error
+}
+
+// FormatOf picks the format by the content type, falling back to the
+// extension of the file name.
+func FormatOf(contentType, name string) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, nil
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatJSONL, nil
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// Read reads every row, at most maxRows of them unless it is 0. Rows with
+// bad values do not stop the import, a bad header or reader does.
+func Read(r io.Reader, format string, maxRows int) ([]Row, error) {
+	var rows []Row
+	add := func(row Row) error {
+		if maxRows > 0 && len(rows) == maxRows {
+			return fmt.Errorf("%w, at most %d are imported at once", ErrTooManyRows, maxRows)
+		}
+		rows = append(rows, row)
+		return nil
+	}
+
+	switch format {
+	case FormatCSV:
+		return rows, readCSV(r, add)
+	case FormatJSONL:
+		return rows, readJSONL(r, add)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func readCSV(r io.Reader, add func(Row) error) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(header))
+	for i, name := range header {
+		names[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if columns[names[i]] == nil {
+			return fmt.Errorf("%w %q", ErrUnknownColumn, names[i])
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !(errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount) {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		if err != nil {
+			row.Err = fmt.Errorf("has %d columns instead of %d", len(record), len(header))
+		} else {
+			row.Err = parseRecord(&row.Animal, names, record)
+		}
+		if err := add(row); err != nil {
+			return err
+		}
+	}
+}
+
+func parseRecord(a *models.AnimalJSON, names []string, record []string) error {
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if err := columns[names[i]](a, value); err != nil {
+			return fmt.Errorf("invalid %s %q", names[i], value)
+		}
+	}
+	return nil
+}
+
+func readJSONL(r io.Reader, add func(Row) error) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			row := Row{Line: line}
+			if jsonErr := json.Unmarshal(data, &row.Animal); jsonErr != nil {
+				row.Err = fmt.Errorf("invalid JSON: %w", jsonErr)
+			}
+			// Exported listings are imported as new ones.
+			row.Animal.ID, row.Animal.OwnerID = 0, nil
+			if err := add(row); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func parseOptional(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// splitPhotos splits a cell of photo references, separated by whitespace or
+// vertical bars. Commas are part of data URIs.
+func splitPhotos(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == '|' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
@@ -0,0 +1,100 @@
+package imports_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/imports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead_CSV(t *testing.T) {
+	data := "\ufeffName, Age,Type,Description,Gender,Vaccinated,Latitude,Fee,Image,Photos\n" +
+		"Luna,2.5,cat,Calm,female,true,50.45,,https://example.com/luna.jpg,luna/1.jpg | luna/2.jpg\n" +
+		"Max,old,dog,Playful,MALE,false,,,,\n" +
+		"Rex,3,dog\n" +
+		"\"Bella\",1,dog,\"Likes walks,\nand kids\",FEMALE,yes,,100,,\n"
+
+	rows, err := imports.Read(strings.NewReader(data), imports.FormatCSV, 0)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+
+	luna := rows[0]
+	assert.Equal(t, 2, luna.Line)
+	assert.NoError(t, luna.Err)
+	assert.Equal(t, "Luna", luna.Animal.Name)
+	assert.Equal(t, float32(2.5), luna.Animal.Age)
+	assert.Equal(t, "FEMALE", luna.Animal.Gender)
+	assert.True(t, luna.Animal.Vaccinated)
+	assert.Equal(t, 50.45, *luna.Animal.Latitude)
+	assert.Nil(t, luna.Animal.Fee)
+	assert.Equal(t, "https://example.com/luna.jpg", luna.Animal.Image)
+	assert.Equal(t, []string{"luna/1.jpg", "luna/2.jpg"}, luna.Animal.Photos)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.EqualError(t, rows[1].Err, `invalid age "old"`)
+	assert.Equal(t, 4, rows[2].Line)
+	assert.EqualError(t, rows[2].Err, "has 3 columns instead of 10")
+
+	bella := rows[3]
+	assert.Equal(t, 5, bella.Line)
+	assert.EqualError(t, bella.Err, `invalid vaccinated "yes"`)
+	assert.Equal(t, "Likes walks,\nand kids", bella.Animal.Description)
+}
+
+func TestRead_CSVUnknownColumn(t *testing.T) {
+	_, err := imports.Read(strings.NewReader("name,breed\nLuna,tabby\n"), imports.FormatCSV, 0)
+	assert.ErrorIs(t, err, imports.ErrUnknownColumn)
+}
+
+func TestRead_JSONL(t *testing.T) {
+	data := `{"id":7,"ownerId":"8c1b0a3e-5d0f-4b8e-9a55-4e8e3f8a1c11","name":"Luna","age":2,"type":"cat","photos":["https://example.com/1.jpg"]}
+
+not json
+{"name":"Max","age":"two"}
+`
+	rows, err := imports.Read(strings.NewReader(data), imports.FormatJSONL, 0)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "Luna", rows[0].Animal.Name)
+	assert.Equal(t, []string{"https://example.com/1.jpg"}, rows[0].Animal.Photos)
+	// Exported listings become new ones.
+	assert.Zero(t, rows[0].Animal.ID)
+	assert.Nil(t, rows[0].Animal.OwnerID)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Error(t, rows[1].Err)
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+}
+
+func TestRead_MaxRows(t *testing.T) {
+	data := "{}\n{}\n{}\n"
+	_, err := imports.Read(strings.NewReader(data), imports.FormatJSONL, 2)
+	assert.ErrorIs(t, err, imports.ErrTooManyRows)
+
+	rows, err := imports.Read(strings.NewReader(data), imports.FormatJSONL, 3)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+}
+
+func TestFormatOf(t *testing.T) {
+	for _, test := range []struct {
+		contentType, name, format string
+	}{
+		{"text/csv; charset=utf-8", "", imports.FormatCSV},
+		{"application/x-ndjson", "", imports.FormatJSONL},
+		{"", "shelter/animals.CSV", imports.FormatCSV},
+		{"application/octet-stream", "export.jsonl", imports.FormatJSONL},
+	} {
+		format, err := imports.FormatOf(test.contentType, test.name)
+		assert.NoError(t, err)
+		assert.Equal(t, test.format, format)
+	}
+
+	_, err := imports.FormatOf("application/json", "animals.xlsx")
+	assert.ErrorIs(t, err, imports.ErrUnknownFormat)
+}
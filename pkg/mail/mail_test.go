@@ -0,0 +1,165 @@
+package mail_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/mail"
+	"github.com/stretchr/testify/assert"
+)
+
+// smtpServer is a local SMTP stand-in that accepts one message per
+// connection and rejects the recipients in reject.
+type smtpServer struct {
+	listener net.Listener
+	reject   map[string]bool
+	messages chan string
+}
+
+func newSMTPServer(t *testing.T, reject ...string) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := &smtpServer{listener: listener, reject: map[string]bool{}, messages: make(chan string, 10)}
+	for _, r := range reject {
+		s.reject[r] = true
+	}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *smtpServer) mailer() *mail.SMTPMailer {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return mail.NewSMTPMailer(host, port, "", "", "noreply@findyourpet.test")
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	reply := func(format string, args ...interface{}) { text.PrintfLine(format, args...) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL", "NOOP", "RSET":
+			reply("250 OK")
+		case "RCPT":
+			address := strings.Trim(strings.TrimPrefix(line[len("RCPT TO:"):], " "), "<>")
+			if s.reject[address] {
+				reply("550 5.1.1 No such user")
+				continue
+			}
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			body, err := text.ReadDotLines()
+			if err != nil {
+				return
+			}
+			s.messages <- strings.Join(body, "\n")
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Unknown command")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := newSMTPServer(t, "gone@example.com")
+	mailer := server.mailer()
+
+	t.Run("Text and HTML", func(t *testing.T) {
+		err := mailer.Send(mail.Email{To: "user@example.com", Subject: "Hello", Text: "Hi there", HTML: "<p>Hi there</p>"})
+		assert.NoError(t, err)
+
+		message := <-server.messages
+		assert.Contains(t, message, "To: user@example.com")
+		assert.Contains(t, message, "Subject: Hello")
+		assert.Contains(t, message, "Content-Type: multipart/alternative")
+		assert.Contains(t, message, "Hi there")
+		assert.Contains(t, message, "<p>Hi there</p>")
+	})
+
+	t.Run("Rejected recipient is permanent", func(t *testing.T) {
+		err := mailer.Send(mail.Email{To: "gone@example.com", Subject: "Hello", Text: "Hi there"})
+		assert.Error(t, err)
+		assert.True(t, mail.IsPermanent(err))
+	})
+
+	t.Run("Unreachable server is temporary", func(t *testing.T) {
+		listener, _ := net.Listen("tcp", "127.0.0.1:0")
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+		listener.Close()
+
+		err := mail.NewSMTPMailer("127.0.0.1", port, "", "", "noreply@findyourpet.test").Send(mail.Email{To: "user@example.com"})
+		assert.Error(t, err)
+		assert.False(t, mail.IsPermanent(err))
+	})
+}
+
+func TestMessage(t *testing.T) {
+	message := string(mail.Message("noreply@findyourpet.test", mail.Email{To: "user@example.com", Subject: "Hi\r\nBcc: x@example.com", Text: "one\ntwo"}))
+
+	assert.Contains(t, message, "Subject: HiBcc: x@example.com\r\n")
+	assert.Contains(t, message, "Content-Type: text/plain")
+	assert.True(t, strings.HasSuffix(message, "one\r\ntwo"))
+	_, err := textproto.NewReader(bufio.NewReader(strings.NewReader(message))).ReadMIMEHeader()
+	assert.NoError(t, err, fmt.Sprintf("%q", message))
+}
+
+func TestRenderer_Render(t *testing.T) {
+	renderer, err := mail.NewRenderer()
+	assert.NoError(t, err)
+
+	t.Run("Locale variant", func(t *testing.T) {
+		email, err := renderer.Render(mail.TemplateWelcome, "uk", map[string]string{"Email": "user@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Вітаємо у FindYourPet", email.Subject)
+		assert.Contains(t, email.Text, "user@example.com")
+	})
+
+	t.Run("Falls back to the default locale", func(t *testing.T) {
+		email, err := renderer.Render(mail.TemplateWelcome, "de", map[string]string{"Email": "user@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Welcome to FindYourPet", email.Subject)
+	})
+
+	t.Run("HTML is escaped", func(t *testing.T) {
+		data := map[string]string{"Title": "New matches", "Body": "<script>", "Link": "https://findyourpet.test"}
+		email, err := renderer.Render(mail.TemplateNotification, "en", data)
+		assert.NoError(t, err)
+		assert.Contains(t, email.Text, "<script>")
+		assert.Contains(t, email.HTML, "&lt;script&gt;")
+		assert.Contains(t, email.HTML, `href="https://findyourpet.test"`)
+	})
+
+	t.Run("Unknown template", func(t *testing.T) {
+		_, err := renderer.Render("missing", "en", nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package mail
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	TemplateWelcome      = "welcome"
+	TemplateNotification = "notification"
+)
+
+// DefaultLocale is used for users without a locale and for templates that
+// have no variant in the user's locale.
+const DefaultLocale = "en"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Renderer renders the embedded email templates. Every template lives in
+// templates/<name>.<locale>.tmpl and defines a "subject", a "text" and an
+// "html" block.
+type Renderer struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	files, err := fs.Glob(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{
+		text: make(map[string]*texttemplate.Template, len(files)),
+		html: make(map[string]*htmltemplate.Template, len(files)),
+	}
+	for _, file := range files {
+		key := strings.TrimSuffix(path.Base(file), ".tmpl")
+		if r.text[key], err = texttemplate.ParseFS(templateFS, file); err != nil {
+			return nil, err
+		}
+		if r.html[key], err = htmltemplate.ParseFS(templateFS, file); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Render renders the template in the locale, falling back to DefaultLocale.
+// The returned email has no recipient.
+func (r *Renderer) Render(name, locale string, data interface{}) (Email, error) {
+	key := name + "." + locale
+	if _, ok := r.text[key]; !ok {
+		key = name + "." + DefaultLocale
+	}
+	text, ok := r.text[key]
+	if !ok {
+		return Email{}, fmt.Errorf("mail: unknown template %q", name)
+	}
+
+	var subject, body, html strings.Builder
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Email{}, err
+	}
+	if err := text.ExecuteTemplate(&body, "text", data); err != nil {
+		return Email{}, err
+	}
+	if err := r.html[key].ExecuteTemplate(&html, "html", data); err != nil {
+		return Email{}, err
+	}
+	return Email{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()),
+		HTML:    strings.TrimSpace(html.String()),
+	}, nil
+}
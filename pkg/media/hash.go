@@ -0,0 +1,35 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+
+	"golang.org/x/image/draw"
+)
+
+// Hash computes the difference hash of the image: it is shrunk to 9x8
+// grey pixels and every bit tells whether a pixel is darker than its right
+// neighbour. Scaled, re-encoded or slightly edited copies of an image hash to
+// values a few bits apart.
+func Hash(img image.Image) uint64 {
+	grey := image.NewGray(image.Rect(0, 0, 9, 8))
+	draw.Draw(grey, grey.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.BiLinear.Scale(grey, grey.Bounds(), img, img.Bounds(), draw.Over, nil)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if grey.GrayAt(x, y).Y < grey.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// Distance is the number of bits two hashes differ in.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,92 @@
+// Package media turns uploaded images into the variants that are served to
+// clients.
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"golang.org/x/image/draw"
+)
+
+// Variant names.
+const (
+	Thumb = "thumb"
+	Card  = "card"
+	Full  = "full"
+)
+
+// Size is a variant whose longer side is at most MaxSide pixels.
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+// Variant is an encoded image of one of the sizes.
+type Variant struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// Result is a processed image.
+type Result struct {
+	Variants []Variant
+	// Hash is the perceptual hash of the upright image.
+	Hash *uint64
+}
+
+// Process turns the decoded image upright and encodes it as JPEG in every
+// size. Images smaller than a size are not scaled up. Re-encoding drops all
+// metadata the original carried, such as the EXIF location. The original
+// data is read for the orientation of JPEG images.
+func Process(src image.Image, data []byte, info uploads.ImageInfo, sizes []Size, quality int) (Result, error) {
+	if info.ContentType == "image/jpeg" {
+		src = orient(src, jpegOrientation(data))
+	}
+
+	variants := make([]Variant, 0, len(sizes))
+	for _, size := range sizes {
+		dst := resize(src, size.MaxSide)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality}); err != nil {
+			return Result{}, err
+		}
+		variants = append(variants, Variant{
+			Name:        size.Name,
+			Data:        buf.Bytes(),
+			ContentType: "image/jpeg",
+			Extension:   ".jpg",
+			Width:       dst.Bounds().Dx(),
+			Height:      dst.Bounds().Dy(),
+		})
+	}
+	hash := Hash(src)
+	return Result{Variants: variants, Hash: &hash}, nil
+}
+
+// resize scales the image down so that its longer side is at most maxSide.
+// The result is always opaque, transparent areas become white.
+func resize(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if longer := max(width, height); longer > maxSide {
+		width = max(1, width*maxSide/longer)
+		height = max(1, height*maxSide/longer)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	if width == b.Dx() && height == b.Dy() {
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Over)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	}
+	return dst
+}
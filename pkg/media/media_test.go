@@ -0,0 +1,148 @@
+package media_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/media"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/stretchr/testify/assert"
+)
+
+var sizes = []media.Size{{Name: media.Thumb, MaxSide: 40}, {Name: media.Full, MaxSide: 400}}
+
+// halves is red on its left half and blue on its right half.
+func halves(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// waves is a smooth grey pattern that differs with the frequency.
+func waves(width, height int, frequency float64) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			u, v := float64(x)/float64(width), float64(y)/float64(height)
+			value := 128 + 60*math.Sin(frequency*u+2*v) + 60*math.Cos(frequency*1.7*v-u)
+			img.SetGray(x, y, color.Gray{Y: uint8(value)})
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 segment carrying the orientation and a GPS
+// latitude reference after the start of the JPEG.
+func withExif(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	ifd := make([]byte, 2+2*12+4)
+	binary.BigEndian.PutUint16(ifd, 2)
+	// Orientation, SHORT, count 1.
+	binary.BigEndian.PutUint16(ifd[2:], 0x0112)
+	binary.BigEndian.PutUint16(ifd[4:], 3)
+	binary.BigEndian.PutUint32(ifd[6:], 1)
+	binary.BigEndian.PutUint16(ifd[10:], orientation)
+	// GPSLatitudeRef, ASCII, count 2.
+	binary.BigEndian.PutUint16(ifd[14:], 0x0001)
+	binary.BigEndian.PutUint16(ifd[16:], 2)
+	binary.BigEndian.PutUint32(ifd[18:], 2)
+	copy(ifd[22:], "N\x00")
+
+	segment := append([]byte("Exif\x00\x00"), append(tiff, ifd...)...)
+	header := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+	return bytes.Join([][]byte{data[:2], header, segment, data[2:]}, nil)
+}
+
+func process(t *testing.T, data []byte) []media.Variant {
+	info, img, err := uploads.ValidateImage(data, 1, 1000, 1000*1000)
+	assert.NoError(t, err)
+	result, err := media.Process(img, data, info, sizes, 90)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Hash)
+	return result.Variants
+}
+
+func decodeVariant(t *testing.T, v media.Variant) image.Image {
+	img, err := jpeg.Decode(bytes.NewReader(v.Data))
+	assert.NoError(t, err)
+	return img
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("Sizes", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, halves(200, 100)))
+
+		variants := process(t, buf.Bytes())
+		assert.Len(t, variants, 2)
+		assert.Equal(t, media.Thumb, variants[0].Name)
+		assert.Equal(t, [2]int{40, 20}, [2]int{variants[0].Width, variants[0].Height})
+		// Smaller images are not scaled up.
+		assert.Equal(t, [2]int{200, 100}, [2]int{variants[1].Width, variants[1].Height})
+		for _, v := range variants {
+			assert.Equal(t, "image/jpeg", v.ContentType)
+			assert.Equal(t, ".jpg", v.Extension)
+			assert.Equal(t, image.Rect(0, 0, v.Width, v.Height), decodeVariant(t, v).Bounds())
+		}
+	})
+
+	t.Run("Orientation is applied and metadata stripped", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, jpeg.Encode(&buf, halves(100, 60), &jpeg.Options{Quality: 95}))
+		data := withExif(buf.Bytes(), 6)
+
+		variants := process(t, data)
+		full := variants[1]
+		assert.Equal(t, [2]int{60, 100}, [2]int{full.Width, full.Height})
+		assert.NotContains(t, string(full.Data), "Exif")
+
+		// Rotated clockwise, the left half ends up on top.
+		img := decodeVariant(t, full)
+		r, _, b, _ := img.At(30, 10).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = img.At(30, 90).RGBA()
+		assert.Greater(t, b, r)
+	})
+
+	t.Run("Transparency becomes white", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 50, 50))))
+
+		r, g, b, _ := decodeVariant(t, process(t, buf.Bytes())[1]).At(25, 25).RGBA()
+		assert.Greater(t, r>>8, uint32(240))
+		assert.Greater(t, g>>8, uint32(240))
+		assert.Greater(t, b>>8, uint32(240))
+	})
+}
+
+func TestHash(t *testing.T) {
+	original := waves(400, 300, 5)
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, original))
+
+	// The thumbnail is scaled down and re-encoded as JPEG, yet it is the
+	// same picture.
+	thumb := decodeVariant(t, process(t, buf.Bytes())[0])
+	assert.LessOrEqual(t, media.Distance(media.Hash(original), media.Hash(thumb)), 2)
+
+	other := waves(400, 300, 11)
+	assert.Greater(t, media.Distance(media.Hash(original), media.Hash(other)), 10)
+
+	assert.Equal(t, 0, media.Distance(42, 42))
+	assert.Equal(t, 64, media.Distance(0, math.MaxUint64))
+}
@@ -0,0 +1,35 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/middleware"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	serve := func(user *models.User) int {
+		router := gin.New()
+		router.GET("/test", func(c *gin.Context) {
+			if user != nil {
+				c.Set("user", user)
+			}
+			c.Next()
+		}, middleware.RequireAdmin(), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"message": "Success"})
+		})
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(&models.User{Role: models.RoleAdmin}))
+	assert.Equal(t, http.StatusForbidden, serve(&models.User{Role: models.RoleUser}))
+	assert.Equal(t, http.StatusForbidden, serve(nil))
+}
@@ -1,9 +1,18 @@
 package models
 
 import (
+	"time"
+
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	AnimalStatusAvailable = "AVAILABLE"
+	AnimalStatusReserved  = "RESERVED"
+	AnimalStatusAdopted   = "ADOPTED"
+)
+
 type Animal struct {
 	gorm.Model
 	Name        string
@@ -13,23 +22,59 @@ type Animal struct {
 	Gender      string
 	Vaccinated  bool
 	Sterilized  bool
+	Latitude    *float64
+	Longitude   *float64
+	Status      string `gorm:"size:16;index;default:AVAILABLE"`
+	Fee         *float64
 	Image       Image
 	Photos      []Photo
+	// Favorite of the requesting user, preloaded only for their favorites list.
+	Favorite *Favorite
+	// ListingChangedAt is bumped by material changes to the listing.
+	ListingChangedAt *time.Time
+	// OwnerID is the user who posted the listing. Listings created before
+	// owners were tracked have none.
+	OwnerID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Populated only by full-text search queries.
+	NameHighlight        string  `gorm:"->;-:migration"`
+	DescriptionHighlight string  `gorm:"->;-:migration"`
+	SearchRank           float32 `gorm:"->;-:migration"`
+	// Distance in kilometers, populated when the request carries a location.
+	Distance *float64 `gorm:"->;-:migration"`
+	// SortName is the name as compared when sorting by name, populated for
+	// that sort only.
+	SortName string `gorm:"->;-:migration"`
 }
 
 // Users       []User `gorm:"many2many:seen_animals;"`
 
 type AnimalJSON struct {
-	ID          uint     `json:"id"`
-	Name        string   `json:"name" binding:"required,alphanum,min=1,max=30"`
-	Age         float32  `json:"age" binding:"required,numeric,min=0,max=30"`
-	Type        string   `json:"type" binding:"required,min=1,max=30"`
-	Description string   `json:"description" binding:"required,max=400"`
-	Gender      string   `json:"gender" binding:"required,uppercase,contains,min=1,max=30"`
-	Vaccinated  bool     `json:"vaccinated"  binding:"boolean"`
-	Sterilized  bool     `json:"sterilized"  binding:"boolean"`
-	Image       string   `json:"image" `
-	Photos      []string `json:"photos"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name" binding:"required,alphanum,min=1,max=30"`
+	Age         float32    `json:"age" binding:"required,numeric,min=0,max=30"`
+	Type        string     `json:"type" binding:"required,min=1,max=30"`
+	Description string     `json:"description" binding:"required,max=400"`
+	Gender      string     `json:"gender" binding:"required,uppercase,contains,min=1,max=30"`
+	Vaccinated  bool       `json:"vaccinated"  binding:"boolean"`
+	Sterilized  bool       `json:"sterilized"  binding:"boolean"`
+	Latitude    *float64   `json:"latitude" binding:"omitempty,latitude"`
+	Longitude   *float64   `json:"longitude" binding:"omitempty,longitude"`
+	Status      string     `json:"status" binding:"omitempty,oneof=AVAILABLE RESERVED ADOPTED"`
+	Fee         *float64   `json:"fee" binding:"omitempty,min=0"`
+	OwnerID     *uuid.UUID `json:"ownerId,omitempty"`
+	Image       string     `json:"image" `
+	Photos      []string   `json:"photos"`
+	// Sizes of the image and photos, only set in responses.
+	ImageSizes *ImageSizesJSON  `json:"imageSizes,omitempty"`
+	PhotoSizes []ImageSizesJSON `json:"photoSizes,omitempty"`
+
+	// Highlights are escaped HTML with the matches in <mark> tags.
+	NameHighlight        string   `json:"nameHighlight,omitempty"`
+	DescriptionHighlight string   `json:"descriptionHighlight,omitempty"`
+	Distance             *float64 `json:"distance,omitempty"`
+
+	Favorite *FavoriteJSON `json:"favorite,omitempty"`
 }
 
 func ToAnimalJSON(a Animal) AnimalJSON {
@@ -42,8 +87,28 @@ func ToAnimalJSON(a Animal) AnimalJSON {
 		Gender:      a.Gender,
 		Vaccinated:  a.Vaccinated,
 		Sterilized:  a.Sterilized,
+		Latitude:    a.Latitude,
+		Longitude:   a.Longitude,
+		Status:      a.Status,
+		Fee:         a.Fee,
+		OwnerID:     a.OwnerID,
 		Image:       a.Image.URL,
 		Photos:      PhotosToArray(a.Photos),
+
+		NameHighlight:        a.NameHighlight,
+		DescriptionHighlight: a.DescriptionHighlight,
+		Distance:             a.Distance,
+	}
+	if a.Image.URL != "" {
+		sizes := ToImageSizesJSON(a.Image.URL, a.Image.Variants)
+		result.ImageSizes = &sizes
+	}
+	if len(a.Photos) > 0 {
+		result.PhotoSizes = PhotoSizesToArray(a.Photos)
+	}
+	if a.Favorite != nil {
+		favorite := ToFavoriteJSON(*a.Favorite)
+		result.Favorite = &favorite
 	}
 	return result
 }
@@ -57,10 +122,63 @@ func FromAnimalJSON(a *AnimalJSON) *Animal {
 		Gender:      a.Gender,
 		Vaccinated:  a.Vaccinated,
 		Sterilized:  a.Sterilized,
+		Latitude:    a.Latitude,
+		Longitude:   a.Longitude,
+		Status:      a.Status,
+		Fee:         a.Fee,
 	}
 	return &result
 }
 
+// AnimalUpdateJSON holds the listing fields that can change after creation.
+// Omitted fields are left as they are.
+type AnimalUpdateJSON struct {
+	Age         *float32 `json:"age" binding:"omitempty,min=0,max=30"`
+	Description *string  `json:"description" binding:"omitempty,max=400"`
+	Vaccinated  *bool    `json:"vaccinated"`
+	Sterilized  *bool    `json:"sterilized"`
+	Latitude    *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" binding:"omitempty,longitude"`
+	Status      *string  `json:"status" binding:"omitempty,oneof=AVAILABLE RESERVED ADOPTED"`
+	Fee         *float64 `json:"fee" binding:"omitempty,min=0"`
+}
+
+// Apply copies the fields set in the update onto the animal.
+func (u *AnimalUpdateJSON) Apply(a *Animal) {
+	if u.Age != nil {
+		a.Age = *u.Age
+	}
+	if u.Description != nil {
+		a.Description = *u.Description
+	}
+	if u.Vaccinated != nil {
+		a.Vaccinated = *u.Vaccinated
+	}
+	if u.Sterilized != nil {
+		a.Sterilized = *u.Sterilized
+	}
+	if u.Latitude != nil {
+		a.Latitude = u.Latitude
+	}
+	if u.Longitude != nil {
+		a.Longitude = u.Longitude
+	}
+	if u.Status != nil {
+		a.Status = *u.Status
+	}
+	if u.Fee != nil {
+		a.Fee = u.Fee
+	}
+}
+
+func (a *Animal) BeforeCreate(tx *gorm.DB) error {
+	if a.ListingChangedAt == nil {
+		now := time.Now()
+		a.ListingChangedAt = &now
+	}
+	return nil
+}
+
 func ToAnimalJSONArray(data []Animal) []AnimalJSON {
 	animals := []AnimalJSON{}
 	for _, a := range data {
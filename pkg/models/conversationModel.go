@@ -0,0 +1,156 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conversation is the thread between an adopter and the owner of an animal
+// listing. There is at most one per adopter and animal.
+type Conversation struct {
+	ID        uint      `gorm:"primarykey"`
+	AnimalID  uint      `gorm:"uniqueIndex:idx_conversations_animal_id_adopter_id"`
+	AdopterID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_conversations_animal_id_adopter_id;index"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;index"`
+	// The last message each participant has read, for read receipts.
+	AdopterLastReadID uint
+	OwnerLastReadID   uint
+	LastMessageAt     time.Time `gorm:"index"`
+	CreatedAt         time.Time
+}
+
+// Participant reports whether the user takes part in the conversation.
+func (c Conversation) Participant(userID uuid.UUID) bool {
+	return c.AdopterID == userID || c.OwnerID == userID
+}
+
+// Other returns the participant that is not the user.
+func (c Conversation) Other(userID uuid.UUID) uuid.UUID {
+	if c.AdopterID == userID {
+		return c.OwnerID
+	}
+	return c.AdopterID
+}
+
+// LastReadBy returns the ID of the last message the participant has read.
+func (c Conversation) LastReadBy(userID uuid.UUID) uint {
+	if c.AdopterID == userID {
+		return c.AdopterLastReadID
+	}
+	return c.OwnerLastReadID
+}
+
+type Message struct {
+	ID             uint      `gorm:"primarykey;index:idx_messages_conversation_id_id,priority:2"`
+	ConversationID uint      `gorm:"index:idx_messages_conversation_id_id,priority:1"`
+	SenderID       uuid.UUID `gorm:"type:uuid;index:idx_messages_sender_id_created_at,priority:1"`
+	Body           string    `gorm:"size:2000"`
+	AttachmentURL  string
+	AttachmentKey  string
+	// AttachmentVariants are the smaller sizes of the attachment.
+	AttachmentVariants ImageVariants `gorm:"embedded;embeddedPrefix:attachment_variant_"`
+	CreatedAt          time.Time     `gorm:"index:idx_messages_sender_id_created_at,priority:2"`
+}
+
+// AttachmentKeys returns the keys of the attachment and its variants.
+func (m Message) AttachmentKeys() []string {
+	return m.AttachmentVariants.keys(m.AttachmentKey)
+}
+
+// Block stops the blocked user from messaging the blocker.
+type Block struct {
+	BlockerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BlockedID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time
+}
+
+// Report flags a participant of a conversation for moderation.
+type Report struct {
+	ID             uint      `gorm:"primarykey"`
+	ReporterID     uuid.UUID `gorm:"type:uuid;index"`
+	ReportedID     uuid.UUID `gorm:"type:uuid;index"`
+	ConversationID uint
+	Reason         string `gorm:"size:400"`
+	CreatedAt      time.Time
+}
+
+type ConversationJSON struct {
+	ID            uint      `json:"id"`
+	AnimalID      uint      `json:"animalId"`
+	AdopterID     uuid.UUID `json:"adopterId"`
+	OwnerID       uuid.UUID `json:"ownerId"`
+	LastMessageAt time.Time `json:"lastMessageAt"`
+	// OtherLastReadID is the last message the other participant has read.
+	OtherLastReadID uint      `json:"otherLastReadId"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type MessageJSON struct {
+	ID         uint      `json:"id"`
+	SenderID   uuid.UUID `json:"senderId"`
+	Body       string    `json:"body"`
+	Attachment string    `json:"attachment,omitempty"`
+	// AttachmentSizes holds the URL of every size of the attachment.
+	AttachmentSizes *ImageSizesJSON `json:"attachmentSizes,omitempty"`
+	// Read is set on the user's own messages the other participant has read.
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type MessageSendJSON struct {
+	Body string `json:"body" binding:"required_without=Attachment,max=2000"`
+	// Attachment is a base64 data URI of a JPEG, PNG or WebP image.
+	Attachment string `json:"attachment"`
+}
+
+type ReportJSON struct {
+	Reason string `json:"reason" binding:"required,max=400"`
+}
+
+// ToConversationJSON presents the conversation to the user.
+func ToConversationJSON(c Conversation, userID uuid.UUID) ConversationJSON {
+	return ConversationJSON{
+		ID:              c.ID,
+		AnimalID:        c.AnimalID,
+		AdopterID:       c.AdopterID,
+		OwnerID:         c.OwnerID,
+		LastMessageAt:   c.LastMessageAt,
+		OtherLastReadID: c.LastReadBy(c.Other(userID)),
+		CreatedAt:       c.CreatedAt,
+	}
+}
+
+func ToConversationJSONArray(conversations []Conversation, userID uuid.UUID) []ConversationJSON {
+	result := make([]ConversationJSON, 0, len(conversations))
+	for _, c := range conversations {
+		result = append(result, ToConversationJSON(c, userID))
+	}
+	return result
+}
+
+// ToMessageJSON presents a message of the conversation to the user.
+func ToMessageJSON(m Message, c Conversation, userID uuid.UUID) MessageJSON {
+	var sizes *ImageSizesJSON
+	if m.AttachmentURL != "" {
+		s := ToImageSizesJSON(m.AttachmentURL, m.AttachmentVariants)
+		sizes = &s
+	}
+	return MessageJSON{
+		ID:              m.ID,
+		SenderID:        m.SenderID,
+		Body:            m.Body,
+		Attachment:      m.AttachmentURL,
+		AttachmentSizes: sizes,
+		Read:            m.SenderID == userID && c.LastReadBy(c.Other(userID)) >= m.ID,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+func ToMessageJSONArray(messages []Message, c Conversation, userID uuid.UUID) []MessageJSON {
+	result := make([]MessageJSON, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, ToMessageJSON(m, c, userID))
+	}
+	return result
+}
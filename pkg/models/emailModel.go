@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+const (
+	EmailPending = "PENDING"
+	EmailSent    = "SENT"
+	// EmailFailed emails ran out of attempts on temporary errors.
+	EmailFailed = "FAILED"
+	// EmailBounced emails were rejected for good by the server.
+	EmailBounced = "BOUNCED"
+	// EmailSuppressed emails were not sent because an earlier email to the
+	// address bounced.
+	EmailSuppressed = "SUPPRESSED"
+)
+
+// OutboxEmail is a rendered email waiting in the outbox, or the record of one
+// that left it. It is written in the same transaction as the change that
+// triggers it, so that no email is lost or sent for a rolled back change.
+type OutboxEmail struct {
+	ID        uint   `gorm:"primarykey"`
+	Recipient string `gorm:"index"`
+	Template  string `gorm:"size:32"`
+	Subject   string
+	Text      string
+	HTML      string
+	Status    string `gorm:"size:16;index:idx_outbox_emails_status_next_attempt_at,priority:1"`
+	Attempts  int
+	// NextAttemptAt is when a pending email is due.
+	NextAttemptAt time.Time `gorm:"index:idx_outbox_emails_status_next_attempt_at,priority:2"`
+	LastError     string
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EmailSuppression is an address that bounced; no more emails are sent to it.
+type EmailSuppression struct {
+	Address   string `gorm:"primaryKey"`
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Favorite struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	AnimalID  uint      `gorm:"primaryKey"`
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type FavoriteJSON struct {
+	Note      string    `json:"note" binding:"max=400"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func ToFavoriteJSON(f Favorite) FavoriteJSON {
+	return FavoriteJSON{
+		Note:      f.Note,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
@@ -9,4 +9,50 @@ type Image struct {
 	AnimalID uint
 	URL      string
 	Key      string
+	Variants ImageVariants `gorm:"embedded;embeddedPrefix:variant_"`
+	// PerceptualHash finds copies of the image, see media.Hash.
+	PerceptualHash *int64
+}
+
+// ImageVariants are the smaller copies of an image. The image itself is
+// the full size. Images uploaded before variants were made have none.
+type ImageVariants struct {
+	ThumbURL string
+	ThumbKey string
+	CardURL  string
+	CardKey  string
+}
+
+// Keys returns the keys of the image and its variants.
+func (i Image) Keys() []string {
+	return i.Variants.keys(i.Key)
+}
+
+func (v ImageVariants) keys(key string) []string {
+	var keys []string
+	for _, key := range []string{key, v.ThumbKey, v.CardKey} {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ImageSizesJSON holds the URL of every size of an image.
+type ImageSizesJSON struct {
+	Thumb string `json:"thumb"`
+	Card  string `json:"card"`
+	Full  string `json:"full"`
+}
+
+// ToImageSizesJSON falls back to the full size for missing variants.
+func ToImageSizesJSON(url string, v ImageVariants) ImageSizesJSON {
+	result := ImageSizesJSON{Thumb: v.ThumbURL, Card: v.CardURL, Full: url}
+	if result.Thumb == "" {
+		result.Thumb = url
+	}
+	if result.Card == "" {
+		result.Card = url
+	}
+	return result
 }
@@ -0,0 +1,25 @@
+package models
+
+// ImportReportJSON tells how a bulk import went. Rows are numbered by their
+// line in the imported file.
+type ImportReportJSON struct {
+	DryRun   bool                   `json:"dryRun"`
+	Rows     int                    `json:"rows"`
+	Valid    int                    `json:"valid"`
+	Imported int                    `json:"imported"`
+	Errors   []ImportRowErrorJSON   `json:"errors"`
+	Warnings []ImportRowWarningJSON `json:"warnings,omitempty"`
+}
+
+type ImportRowErrorJSON struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportRowWarningJSON lists the listings an imported one shares pictures
+// with.
+type ImportRowWarningJSON struct {
+	Row        int                    `json:"row"`
+	AnimalID   uint                   `json:"animalId"`
+	Duplicates []DuplicateWarningJSON `json:"duplicates"`
+}
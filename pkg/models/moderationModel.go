@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Moderation reasons.
+const (
+	ModerationDuplicatePhoto = "duplicate_photo"
+)
+
+// Moderation statuses. Resolved items needed action, dismissed ones were
+// flagged by mistake.
+const (
+	ModerationPending   = "pending"
+	ModerationResolved  = "resolved"
+	ModerationDismissed = "dismissed"
+)
+
+// ModerationItem queues a listing for moderators to review.
+type ModerationItem struct {
+	ID       uint `gorm:"primarykey"`
+	AnimalID uint `gorm:"index"`
+	Reason   string
+	// DuplicateOfID is the earlier listing with a near-identical image, and
+	// Distance the number of bits their image hashes differ in.
+	DuplicateOfID *uint
+	Distance      int
+	Status        string `gorm:"index;not null;default:pending"`
+	CreatedAt     time.Time
+	// ResolvedBy is the moderator who resolved or dismissed the item.
+	ResolvedBy *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt *time.Time
+}
+
+type ModerationItemJSON struct {
+	ID            uint       `json:"id"`
+	AnimalID      uint       `json:"animalId"`
+	Reason        string     `json:"reason"`
+	DuplicateOfID *uint      `json:"duplicateOfId,omitempty"`
+	Distance      int        `json:"distance"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ResolvedBy    *uuid.UUID `json:"resolvedBy,omitempty"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// ModerationResolutionJSON closes an item of the moderation queue.
+type ModerationResolutionJSON struct {
+	Status string `json:"status" binding:"required,oneof=resolved dismissed"`
+}
+
+func ToModerationItemJSON(i ModerationItem) ModerationItemJSON {
+	return ModerationItemJSON{
+		ID:            i.ID,
+		AnimalID:      i.AnimalID,
+		Reason:        i.Reason,
+		DuplicateOfID: i.DuplicateOfID,
+		Distance:      i.Distance,
+		Status:        i.Status,
+		CreatedAt:     i.CreatedAt,
+		ResolvedBy:    i.ResolvedBy,
+		ResolvedAt:    i.ResolvedAt,
+	}
+}
+
+func ToModerationItemJSONArray(items []ModerationItem) []ModerationItemJSON {
+	result := make([]ModerationItemJSON, 0, len(items))
+	for _, i := range items {
+		result = append(result, ToModerationItemJSON(i))
+	}
+	return result
+}
+
+// SimilarAnimal is a listing with an image close to one being checked.
+type SimilarAnimal struct {
+	AnimalID uint
+	Distance int
+}
+
+// DuplicateWarningJSON tells the poster that the new listing shows an image
+// of another one.
+type DuplicateWarningJSON struct {
+	AnimalID uint `json:"animalId"`
+	Distance int  `json:"distance"`
+}
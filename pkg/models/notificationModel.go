@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationTypeSavedSearch  = "SAVED_SEARCH"
+	NotificationTypeAnimalStatus = "ANIMAL_STATUS"
+)
+
+// Notification is a message to a user, kept in their in-app inbox.
+type Notification struct {
+	ID     uint      `gorm:"primarykey;index:idx_notifications_user_id_id,priority:2"`
+	UserID uuid.UUID `gorm:"type:uuid;index:idx_notifications_user_id_id,priority:1"`
+	// Type tells clients how to present the notification.
+	Type  string `gorm:"size:32"`
+	Title string
+	Body  string
+	Link  string
+	// AnimalID is the animal the notification is about, if any.
+	AnimalID  *uint
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+type NotificationJSON struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link,omitempty"`
+	AnimalID  *uint      `json:"animalId,omitempty"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// NotificationsPage is a page of the inbox together with the number of
+// notifications the user has not read yet.
+type NotificationsPage struct {
+	PaginatedContent[NotificationJSON]
+	Unread int64 `json:"unread"`
+}
+
+func ToNotificationJSON(n Notification) NotificationJSON {
+	return NotificationJSON{
+		ID:        n.ID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Link:      n.Link,
+		AnimalID:  n.AnimalID,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func ToNotificationJSONArray(notifications []Notification) []NotificationJSON {
+	result := make([]NotificationJSON, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, ToNotificationJSON(n))
+	}
+	return result
+}
@@ -1,8 +1,10 @@
 package models
 
 type PaginatedContent[T any] struct {
-	Data       []T `json:"data"`
-	Page       int `json:"page"`
-	PageSize   int `json:"pageSize"`
-	TotalPages int `json:"totalPages"`
+	Data       []T    `json:"data"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	TotalPages int    `json:"totalPages"`
+	Cursor     string `json:"cursor,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -8,7 +10,80 @@ type Photo struct {
 	gorm.Model
 	AnimalID uint
 	ImageURL string
-	Key      string // s3 upload id
+	Key      string        // s3 upload id
+	Variants ImageVariants `gorm:"embedded;embeddedPrefix:variant_"`
+	// Position orders the photos of the animal, lowest first.
+	Position int `gorm:"not null;default:0"`
+	// PerceptualHash finds copies of the photo, see media.Hash. Photos
+	// stored before hashing, such as HEIC uploads, have none.
+	PerceptualHash *int64
+}
+
+type PhotoJSON struct {
+	ID       uint           `json:"id"`
+	URL      string         `json:"url"`
+	Key      string         `json:"key"`
+	Sizes    ImageSizesJSON `json:"sizes"`
+	Position int            `json:"position"`
+}
+
+// PhotoUploadJSON describes a photo the client wants to upload directly to storage.
+type PhotoUploadJSON struct {
+	// ContentType is image/jpeg, image/png or image/webp. HEIC photos are
+	// not supported and have to be converted first.
+	ContentType string `json:"contentType" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+type PhotoUploadsJSON struct {
+	Files []PhotoUploadJSON `json:"files" binding:"required,min=1,dive"`
+}
+
+// PhotoConfirmJSON registers directly uploaded photos with their animal.
+type PhotoConfirmJSON struct {
+	Keys []string `json:"keys" binding:"required,min=1,dive,required"`
+}
+
+// PhotoPositionJSON moves a photo of the animal to the position.
+type PhotoPositionJSON struct {
+	ID       uint `json:"id" binding:"required"`
+	Position int  `json:"position" binding:"min=0"`
+}
+
+type PhotoOrderJSON struct {
+	Photos []PhotoPositionJSON `json:"photos" binding:"required,min=1,dive"`
+}
+
+// CoverJSON chooses the photo shown as the animal's image.
+type CoverJSON struct {
+	PhotoID uint `json:"photoId" binding:"required"`
+}
+
+// PresignedUpload is a signed request the client sends a file to storage
+// with. Headers have to be sent as they are.
+type PresignedUpload struct {
+	Key       string            `json:"key"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+// Keys returns the keys of the photo and its variants.
+func (p Photo) Keys() []string {
+	return p.Variants.keys(p.Key)
+}
+
+func ToPhotoJSON(p Photo) PhotoJSON {
+	return PhotoJSON{ID: p.ID, URL: p.ImageURL, Key: p.Key, Sizes: ToImageSizesJSON(p.ImageURL, p.Variants), Position: p.Position}
+}
+
+func ToPhotoJSONArray(photos []Photo) []PhotoJSON {
+	result := make([]PhotoJSON, 0, len(photos))
+	for _, p := range photos {
+		result = append(result, ToPhotoJSON(p))
+	}
+	return result
 }
 
 func PhotosToArray(photos []Photo) (urls []string) {
@@ -17,3 +92,11 @@ func PhotosToArray(photos []Photo) (urls []string) {
 	}
 	return urls
 }
+
+func PhotoSizesToArray(photos []Photo) []ImageSizesJSON {
+	result := make([]ImageSizesJSON, 0, len(photos))
+	for _, p := range photos {
+		result = append(result, ToImageSizesJSON(p.ImageURL, p.Variants))
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedSearch is a named set of listing filters that is periodically run
+// against newly created animals.
+type SavedSearch struct {
+	gorm.Model
+	UserID      uuid.UUID `gorm:"type:uuid;index"`
+	Name        string    `gorm:"size:100"`
+	Query       string    // the filters, URL encoded
+	NotifyInApp bool
+	NotifyEmail bool
+	// UnsubscribeToken authenticates the unsubscribe link sent in digests.
+	UnsubscribeToken string `gorm:"size:64;uniqueIndex"`
+	LastRunAt        time.Time
+}
+
+type SavedSearchJSON struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name" binding:"required,max=100"`
+	Filters     map[string]string `json:"filters"`
+	NotifyInApp bool              `json:"notifyInApp"`
+	NotifyEmail bool              `json:"notifyEmail"`
+	LastRunAt   time.Time         `json:"lastRunAt"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// Values returns the stored filters as listing query parameters.
+func (s SavedSearch) Values() url.Values {
+	values, _ := url.ParseQuery(s.Query)
+	return values
+}
+
+func FromSavedSearchJSON(s SavedSearchJSON) SavedSearch {
+	values := url.Values{}
+	for key, value := range s.Filters {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return SavedSearch{
+		Name:        s.Name,
+		Query:       values.Encode(),
+		NotifyInApp: s.NotifyInApp,
+		NotifyEmail: s.NotifyEmail,
+	}
+}
+
+func ToSavedSearchJSON(s SavedSearch) SavedSearchJSON {
+	filters := map[string]string{}
+	values := s.Values()
+	for key := range values {
+		filters[key] = values.Get(key)
+	}
+	return SavedSearchJSON{
+		ID:          s.ID,
+		Name:        s.Name,
+		Filters:     filters,
+		NotifyInApp: s.NotifyInApp,
+		NotifyEmail: s.NotifyEmail,
+		LastRunAt:   s.LastRunAt,
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func ToSavedSearchJSONArray(searches []SavedSearch) []SavedSearchJSON {
+	result := make([]SavedSearchJSON, 0, len(searches))
+	for _, s := range searches {
+		result = append(result, ToSavedSearchJSON(s))
+	}
+	return result
+}
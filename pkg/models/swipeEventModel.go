@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SwipeActionLike = "like"
+	SwipeActionPass = "pass"
+	SwipeActionUndo = "undo"
+)
+
+// SwipeEvent is an append-only record of a swipe. It keeps the seen_animals
+// state from before the swipe so that the swipe can be undone.
+type SwipeEvent struct {
+	ID            uint      `gorm:"primarykey;index:idx_swipe_events_user_id_id,priority:2"`
+	UserID        uuid.UUID `gorm:"type:uuid;index:idx_swipe_events_user_id_id,priority:1"`
+	AnimalID      uint
+	Action        string `gorm:"size:16"`
+	Client        string `gorm:"size:255"`
+	CreatedAt     time.Time
+	UndoneEventID *uint
+
+	PreviousLiked  *bool
+	PreviousSeenAt *time.Time
+	AddedFavorite  bool
+}
+
+type SwipeEventJSON struct {
+	ID            uint      `json:"id"`
+	AnimalID      uint      `json:"animalId"`
+	Action        string    `json:"action"`
+	Client        string    `json:"client"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UndoneEventID *uint     `json:"undoneEventId,omitempty"`
+}
+
+func ToSwipeEventJSON(e SwipeEvent) SwipeEventJSON {
+	return SwipeEventJSON{
+		ID:            e.ID,
+		AnimalID:      e.AnimalID,
+		Action:        e.Action,
+		Client:        e.Client,
+		CreatedAt:     e.CreatedAt,
+		UndoneEventID: e.UndoneEventID,
+	}
+}
+
+func ToSwipeEventJSONArray(data []SwipeEvent) []SwipeEventJSON {
+	events := []SwipeEventJSON{}
+	for _, e := range data {
+		events = append(events, ToSwipeEventJSON(e))
+	}
+	return events
+}
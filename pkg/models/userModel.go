@@ -5,6 +5,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	gorm.Model
 	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
@@ -12,11 +18,22 @@ type User struct {
 	Password     string
 	UserSettings UserSettings
 	SeenAnimals  []Animal `gorm:"many2many:seen_animals;"`
+
+	// Locale picks the language of the emails sent to the user.
+	Locale string `gorm:"size:8;default:en"`
+	// Role is RoleAdmin for moderators. Admins are created from the command
+	// line only.
+	Role string `gorm:"size:16;not null;default:user"`
+}
+
+func (u User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
 
 type UserSingupJSON struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=5,max=30"`
+	Locale   string `json:"locale" binding:"omitempty,oneof=en uk"`
 }
 
 type UserJSON struct {
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+// Webhook posts the selected events about the user's animals to a URL.
+type Webhook struct {
+	gorm.Model
+	UserID uuid.UUID `gorm:"type:uuid;index"`
+	URL    string
+	// Secret keys the HMAC signature of every payload.
+	Secret string `gorm:"size:64"`
+	// Events is the comma separated list of subscribed event types.
+	Events string
+	Active bool
+}
+
+func (w Webhook) EventList() []string {
+	if w.Events == "" {
+		return []string{}
+	}
+	return strings.Split(w.Events, ",")
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook.
+type WebhookDelivery struct {
+	ID        uint   `gorm:"primarykey;index:idx_webhook_deliveries_webhook_id_id,priority:2"`
+	WebhookID uint   `gorm:"index:idx_webhook_deliveries_webhook_id_id,priority:1"`
+	EventType string `gorm:"size:32"`
+	Payload   string
+	Status    string `gorm:"size:16"`
+	Attempts  int
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt  time.Time `gorm:"index"`
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Webhook *Webhook
+}
+
+type WebhookJSON struct {
+	ID     uint     `json:"id"`
+	URL    string   `json:"url" binding:"required,url,startswith=http"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=animal.created animal.liked status.changed"`
+	Active bool     `json:"active"`
+	// Secret is only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveryJSON struct {
+	ID             uint       `json:"id"`
+	EventType      string     `json:"eventType"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	LastStatusCode int        `json:"lastStatusCode,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func FromWebhookJSON(w WebhookJSON) Webhook {
+	return Webhook{URL: w.URL, Events: strings.Join(w.Events, ","), Active: true}
+}
+
+func ToWebhookJSON(w Webhook) WebhookJSON {
+	return WebhookJSON{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.EventList(),
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func ToWebhookJSONArray(webhooks []Webhook) []WebhookJSON {
+	result := make([]WebhookJSON, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, ToWebhookJSON(w))
+	}
+	return result
+}
+
+func ToWebhookDeliveryJSON(d WebhookDelivery) WebhookDeliveryJSON {
+	result := WebhookDeliveryJSON{
+		ID:             d.ID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == WebhookDeliveryPending {
+		result.NextAttemptAt = &d.NextAttemptAt
+	}
+	return result
+}
+
+func ToWebhookDeliveryJSONArray(deliveries []WebhookDelivery) []WebhookDeliveryJSON {
+	result := make([]WebhookDeliveryJSON, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, ToWebhookDeliveryJSON(d))
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+// Package netguard keeps requests to URLs given by users off the internal
+// network.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrUnsupportedURL = errors.New("only http and https URLs are supported")
+	ErrPrivateAddress = errors.New("address is not public")
+)
+
+// blockedNets are the ranges that are global unicast but not reachable on
+// the internet, beside the private ones.
+var blockedNets = []*net.IPNet{
+	// Carrier-grade NAT, which cloud providers also use internally.
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+// NewPublicClient returns a client that only connects to public addresses,
+// so that URLs given by users can not reach the internal network.
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// Redirects are dialed through the same check, they may only
+		// change the scheme to another web one.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrUnsupportedURL
+			}
+			return nil
+		},
+	}
+}
+
+// CheckPublicURL fails unless the URL is a web URL whose host only resolves
+// to public addresses. Clients from NewPublicClient check every connection
+// again, as the host may resolve differently later.
+func CheckPublicURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Hostname() == "" {
+		return ErrUnsupportedURL
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !isPublic(addr.IP) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, u.Hostname())
+		}
+	}
+	return nil
+}
+
+// isPublic tells whether the address is reachable on the internet, rather
+// than loopback, link-local as the cloud metadata service, private or
+// carrier-grade NAT.
+func isPublic(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
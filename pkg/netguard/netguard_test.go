@@ -0,0 +1,38 @@
+package netguard_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The test server listens on a loopback address.
+	_, err := netguard.NewPublicClient(time.Second).Get(server.URL)
+	assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+}
+
+func TestCheckPublicURL(t *testing.T) {
+	assert.NoError(t, netguard.CheckPublicURL("https://93.184.216.34/hooks"))
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hooks",
+		"http://localhost/hooks",
+		"http://10.0.0.5/hooks",
+		"http://192.168.1.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.100.100.200/latest/meta-data",
+		"http://[::1]/hooks",
+		"http://0.0.0.0/hooks",
+	} {
+		assert.ErrorIs(t, netguard.CheckPublicURL(rawURL), netguard.ErrPrivateAddress, rawURL)
+	}
+	assert.ErrorIs(t, netguard.CheckPublicURL("ftp://93.184.216.34/hooks"), netguard.ErrUnsupportedURL)
+}
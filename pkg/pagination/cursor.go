@@ -0,0 +1,160 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// KeysetColumn is an expression results are ordered by. Type is the SQL type
+// cursor values are cast to when they are compared with the expression.
+type KeysetColumn struct {
+	Expr string
+	Args []interface{}
+	Type string
+	Desc bool
+}
+
+// Cursor is the position after the last row of a page. It is opaque to clients.
+type Cursor struct {
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	var cursor Cursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// IsCursorMode reports whether the client asked for keyset pagination. An
+// empty cursor parameter requests the first page.
+func IsCursorMode(c *gin.Context) bool {
+	_, ok := c.GetQuery(constants.Cursor)
+	return ok
+}
+
+// OrderBy builds the ORDER BY clause for the keyset columns.
+func OrderBy(columns []KeysetColumn) clause.OrderBy {
+	exprs := make([]string, 0, len(columns))
+	var args []interface{}
+	for _, column := range columns {
+		direction := "ASC"
+		if column.Desc {
+			direction = "DESC"
+		}
+		exprs = append(exprs, column.Expr+" "+direction)
+		args = append(args, column.Args...)
+	}
+
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(exprs, ", "),
+		Vars:               args,
+		WithoutParentheses: true,
+	}}
+}
+
+// PaginateKeyset returns the page following the request cursor. All columns
+// must share one direction and the last one must be unique. One extra row is
+// fetched so that NextPage can tell whether another page exists.
+func PaginateKeyset(c *gin.Context, order string, columns []KeysetColumn) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		pageSize, err := strconv.Atoi(c.DefaultQuery(constants.PageSize, "10"))
+		if err != nil || pageSize < 1 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid page size"})
+			_ = db.AddError(errors.New("invalid page size"))
+			return db
+		}
+		pageSize = clampPageSize(pageSize)
+
+		if withTotal(c, false) {
+			dbClone := db.Session(&gorm.Session{})
+			var total int64
+			dbClone.Count(&total)
+
+			c.Set("totalPages", CalculateTotalPages(int(total), pageSize))
+		}
+
+		if raw := c.Query(constants.Cursor); raw != "" {
+			cursor, err := DecodeCursor(raw)
+			if err != nil || cursor.Order != order || len(cursor.Values) != len(columns) {
+				_ = db.AddError(ErrInvalidCursor)
+				return db
+			}
+			condition, err := keysetCondition(columns, cursor.Values)
+			if err != nil {
+				_ = db.AddError(err)
+				return db
+			}
+			db = db.Where(condition)
+			c.Set("cursor", raw)
+		}
+
+		c.Set("pageSize", pageSize)
+
+		return db.Limit(pageSize + 1)
+	}
+}
+
+// keysetCondition compares the row value of the columns with the cursor.
+func keysetCondition(columns []KeysetColumn, values []string) (clause.Expr, error) {
+	exprs := make([]string, 0, len(columns))
+	placeholders := make([]string, 0, len(columns))
+	var args []interface{}
+	for _, column := range columns {
+		if column.Desc != columns[0].Desc {
+			return clause.Expr{}, errors.New("keyset columns must share one sort direction")
+		}
+		exprs = append(exprs, column.Expr)
+		args = append(args, column.Args...)
+	}
+	for i, column := range columns {
+		placeholders = append(placeholders, fmt.Sprintf("CAST(CAST(? AS text) AS %s)", column.Type))
+		args = append(args, values[i])
+	}
+
+	operator := ">"
+	if columns[0].Desc {
+		operator = "<"
+	}
+
+	return clause.Expr{
+		SQL:  fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), operator, strings.Join(placeholders, ", ")),
+		Vars: args,
+	}, nil
+}
+
+// NextPage drops the extra row fetched by PaginateKeyset and, when there is
+// one, stores the cursor pointing past the last returned row.
+func NextPage[T any](c *gin.Context, order string, rows []T, values func(T) []string) []T {
+	pageSize := c.GetInt("pageSize")
+	if pageSize <= 0 || len(rows) <= pageSize {
+		return rows
+	}
+
+	rows = rows[:pageSize]
+	c.Set("nextCursor", EncodeCursor(Cursor{Order: order, Values: values(rows[len(rows)-1])}))
+	return rows
+}
@@ -0,0 +1,79 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncoding(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		cursor := pagination.Cursor{Order: "newest", Values: []string{"2024-03-01T10:00:00.123456Z", "42"}}
+
+		decoded, err := pagination.DecodeCursor(pagination.EncodeCursor(cursor))
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		_, err := pagination.DecodeCursor("not a cursor!")
+
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
+
+func TestIsCursorMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{query: "/", expected: false},
+		{query: "/?page=2", expected: false},
+		{query: "/?cursor=", expected: true},
+		{query: "/?cursor=abc", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest("GET", tt.query, nil)
+
+			assert.Equal(t, tt.expected, pagination.IsCursorMode(c))
+		})
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	values := func(id int) []string { return []string{strconv.Itoa(id)} }
+
+	t.Run("extra row produces a cursor", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("pageSize", 2)
+
+		rows := pagination.NextPage(c, "newest", []int{5, 4, 3}, values)
+
+		assert.Equal(t, []int{5, 4}, rows)
+		cursor, err := pagination.DecodeCursor(c.GetString("nextCursor"))
+		assert.NoError(t, err)
+		assert.Equal(t, pagination.Cursor{Order: "newest", Values: []string{"4"}}, cursor)
+	})
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Set("pageSize", 2)
+
+		rows := pagination.NextPage(c, "newest", []int{5, 4}, values)
+
+		assert.Equal(t, []int{5, 4}, rows)
+		assert.Empty(t, c.GetString("nextCursor"))
+	})
+}
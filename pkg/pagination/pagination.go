@@ -23,24 +23,20 @@ func Paginate(c *gin.Context) func(db *gorm.DB) *gorm.DB {
 		if page < 0 {
 			page = 1
 		}
-		switch {
-		case pageSize > 100:
-			pageSize = 100
-		case pageSize <= 0:
-			pageSize = 10
-		}
+		pageSize = clampPageSize(pageSize)
 
 		offset := (page - 1) * pageSize
 
-		dbClone := db.Session(&gorm.Session{})
-		var total int64
-		dbClone.Count(&total)
+		if withTotal(c, true) {
+			dbClone := db.Session(&gorm.Session{})
+			var total int64
+			dbClone.Count(&total)
 
-		totalPages := CalculateTotalPages(int(total), pageSize)
+			c.Set("totalPages", CalculateTotalPages(int(total), pageSize))
+		}
 
 		c.Set("page", page)
 		c.Set("pageSize", pageSize)
-		c.Set("totalPages", totalPages)
 
 		return db.Offset(offset).Limit(pageSize)
 	}
@@ -64,6 +60,26 @@ func GetPageQueryParams(c *gin.Context) (int, int, error) {
 	return page, pageSize, nil
 }
 
+func clampPageSize(pageSize int) int {
+	switch {
+	case pageSize > 100:
+		return 100
+	case pageSize <= 0:
+		return 10
+	}
+	return pageSize
+}
+
+// withTotal reports whether the client wants the total page count, which
+// costs an extra COUNT query.
+func withTotal(c *gin.Context, defaultValue bool) bool {
+	v, err := strconv.ParseBool(c.Query(constants.WithTotal))
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
 func CalculateTotalPages(totalElements, size int) int {
 	if totalElements < 0 {
 		// Handle negative total elements
@@ -77,3 +93,23 @@ func CalculateTotalPages(totalElements, size int) int {
 
 	return int(math.Ceil(float64(totalElements) / float64(size)))
 }
+
+// PageSlice returns the requested page of rows that were already loaded and
+// ordered in memory, setting the same context values as Paginate.
+func PageSlice[T any](c *gin.Context, rows []T) []T {
+	page, pageSize, err := GetPageQueryParams(c)
+	if err != nil {
+		return []T{}
+	}
+	pageSize = clampPageSize(pageSize)
+
+	c.Set("page", page)
+	c.Set("pageSize", pageSize)
+	c.Set("totalPages", CalculateTotalPages(len(rows), pageSize))
+
+	offset := (page - 1) * pageSize
+	if offset >= len(rows) {
+		return []T{}
+	}
+	return rows[offset:min(offset+pageSize, len(rows))]
+}
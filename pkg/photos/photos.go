@@ -0,0 +1,182 @@
+// Package photos validates, processes and stores the photos of animals and
+// messages in a blob store.
+package photos
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/media"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidDataURI = errors.New("photo must be a base64 data URI")
+
+type PhotoServiceI interface {
+	UploadSinglePhoto(photoURI string, prefix string) (models.Photo, error)
+	UploadPhotoFromReader(file io.Reader, prefix string) (models.Photo, error)
+	PresignPhotoUpload(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error)
+	GetPhotoInfo(key string) (storage.Object, error)
+	ProcessUploadedPhoto(key, contentType string) (models.Photo, error)
+	DeletePhoto(key string) error
+}
+
+// photoSizes are the variants every photo is stored in.
+var photoSizes = []media.Size{
+	{Name: media.Thumb, MaxSide: constants.ThumbImageSide},
+	{Name: media.Card, MaxSide: constants.CardImageSide},
+	{Name: media.Full, MaxSide: constants.FullImageSide},
+}
+
+type PhotoService struct {
+	store storage.BlobStoreI
+}
+
+func NewPhotoService(store storage.BlobStoreI) *PhotoService {
+	return &PhotoService{store: store}
+}
+
+// UploadSinglePhoto stores a single photo given as a base64 data URI.
+func (s *PhotoService) UploadSinglePhoto(photoURI string, prefix string) (models.Photo, error) {
+	valid, decodedData, err := s.validateAndDecodeBase64(photoURI)
+	if err != nil || !valid {
+		return models.Photo{}, err
+	}
+	return s.storePhoto(decodedData, s.createFilename(prefix))
+}
+
+// UploadPhotoFromReader stores a photo read from file. The photo is read
+// whole to validate it, so the caller is responsible for limiting its size.
+func (s *PhotoService) UploadPhotoFromReader(file io.Reader, prefix string) (models.Photo, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return models.Photo{}, err
+	}
+	return s.storePhoto(data, s.createFilename(prefix))
+}
+
+// PresignPhotoUpload returns a request the client can upload a photo of
+// exactly that type and size with, without going through the API.
+func (s *PhotoService) PresignPhotoUpload(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error) {
+	return s.store.PresignPut(key, contentType, size, expires)
+}
+
+// GetPhotoInfo looks up an uploaded photo, returning storage.ErrNotFound
+// when there is none under the key.
+func (s *PhotoService) GetPhotoInfo(key string) (storage.Object, error) {
+	return s.store.Head(key)
+}
+
+// ProcessUploadedPhoto downloads a directly uploaded photo, checks that it
+// is a well-formed image of the type it was stored as and stores its sizes
+// next to it. The caller deletes the original once the photo is saved.
+func (s *PhotoService) ProcessUploadedPhoto(key, contentType string) (models.Photo, error) {
+	object, err := s.store.Get(key)
+	if err != nil {
+		return models.Photo{}, err
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(io.LimitReader(object, constants.MaxPhotoSize+1))
+	if err != nil {
+		return models.Photo{}, err
+	}
+	if len(data) > constants.MaxPhotoSize {
+		return models.Photo{}, uploads.ErrFileTooLarge
+	}
+	info, img, err := uploads.ValidateImage(data, constants.MinImageSide, constants.MaxImageSide, constants.MaxImagePixels)
+	if err != nil {
+		return models.Photo{}, err
+	}
+	if info.ContentType != contentType {
+		return models.Photo{}, fmt.Errorf("%w: %s is stored as %s but is %s", uploads.ErrUnsupportedType, key, contentType, info.ContentType)
+	}
+	return s.uploadVariants(img, data, info, strings.TrimSuffix(key, path.Ext(key)))
+}
+
+// DeletePhoto removes a stored object.
+func (s *PhotoService) DeletePhoto(key string) error {
+	return s.store.Delete(key)
+}
+
+// storePhoto validates the photo and uploads its sizes. The original is not
+// stored, so none of its metadata is served.
+func (s *PhotoService) storePhoto(data []byte, filename string) (models.Photo, error) {
+	info, img, err := uploads.ValidateImage(data, constants.MinImageSide, constants.MaxImageSide, constants.MaxImagePixels)
+	if err != nil {
+		return models.Photo{}, err
+	}
+	return s.uploadVariants(img, data, info, filename)
+}
+
+// uploadVariants processes the photo and uploads every size under the
+// filename suffixed with the size name.
+func (s *PhotoService) uploadVariants(img image.Image, data []byte, info uploads.ImageInfo, filename string) (models.Photo, error) {
+	result, err := media.Process(img, data, info, photoSizes, constants.ImageQuality)
+	if err != nil {
+		return models.Photo{}, err
+	}
+
+	var photo models.Photo
+	if result.Hash != nil {
+		hash := int64(*result.Hash)
+		photo.PerceptualHash = &hash
+	}
+	for _, variant := range result.Variants {
+		object, err := s.store.Put(filename+"_"+variant.Name+variant.Extension, bytes.NewReader(variant.Data), variant.ContentType)
+		if err != nil {
+			return models.Photo{}, err
+		}
+		switch variant.Name {
+		case media.Thumb:
+			photo.Variants.ThumbURL, photo.Variants.ThumbKey = object.URL, object.Key
+		case media.Card:
+			photo.Variants.CardURL, photo.Variants.CardKey = object.URL, object.Key
+		default:
+			photo.ImageURL, photo.Key = object.URL, object.Key
+		}
+	}
+	return photo, nil
+}
+
+func (s *PhotoService) validateAndDecodeBase64(photoURI string) (bool, []byte, error) {
+	data, err := extractBase64Part(photoURI)
+	if err != nil {
+		return false, nil, err
+	}
+	decodedData, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return false, nil, fmt.Errorf("%w: %v", ErrInvalidDataURI, err)
+	}
+	if len(decodedData) > constants.MaxPhotoSize {
+		return false, nil, uploads.ErrFileTooLarge
+	}
+	return true, decodedData, nil
+}
+
+// extractBase64Part returns the data of a "data:<type>;base64,<data>" URI.
+// The declared type is ignored; the content is sniffed instead.
+func extractBase64Part(dataURI string) (string, error) {
+	meta, data, found := strings.Cut(dataURI, ",")
+	if !found || !strings.HasPrefix(meta, "data:") || !strings.HasSuffix(meta, ";base64") {
+		return "", ErrInvalidDataURI
+	}
+	return data, nil
+}
+
+func (s *PhotoService) createFilename(namePrefix string) string {
+	id := uuid.New()
+	result := fmt.Sprintf("%s_%s", namePrefix, id)
+	return result
+}
@@ -0,0 +1,73 @@
+package photos_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/photos"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestPhotoService_UploadSinglePhoto(t *testing.T) {
+	store := storage.NewMemoryStore("memory://")
+	service := photos.NewPhotoService(store)
+
+	t.Run("Every size is stored", func(t *testing.T) {
+		uri := "data:image/png;base64," + base64.StdEncoding.EncodeToString(encodePNG(t, 1000, 500))
+		photo, err := service.UploadSinglePhoto(uri, "Luna")
+		assert.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(photo.Key, "Luna_"))
+		assert.True(t, strings.HasSuffix(photo.Key, "_full.jpg"))
+		assert.Equal(t, "memory://"+photo.Key, photo.ImageURL)
+		for _, key := range []string{photo.Key, photo.Variants.ThumbKey, photo.Variants.CardKey} {
+			object, err := store.Head(key)
+			assert.NoError(t, err)
+			assert.Equal(t, "image/jpeg", object.ContentType)
+		}
+	})
+
+	t.Run("Invalid photos", func(t *testing.T) {
+		_, err := service.UploadSinglePhoto("not a data uri", "Luna")
+		assert.ErrorIs(t, err, photos.ErrInvalidDataURI)
+		_, err = service.UploadSinglePhoto("data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte("%PDF-1.7")), "Luna")
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+	})
+}
+
+func TestPhotoService_ProcessUploadedPhoto(t *testing.T) {
+	store := storage.NewMemoryStore("memory://")
+	service := photos.NewPhotoService(store)
+	key := "animals/3/uploads/a.png"
+	_, err := store.Put(key, bytes.NewReader(encodePNG(t, 64, 64)), "image/png")
+	assert.NoError(t, err)
+
+	t.Run("Sizes are stored next to the upload", func(t *testing.T) {
+		photo, err := service.ProcessUploadedPhoto(key, "image/png")
+		assert.NoError(t, err)
+		assert.Equal(t, "animals/3/uploads/a_full.jpg", photo.Key)
+		assert.Equal(t, "animals/3/uploads/a_thumb.jpg", photo.Variants.ThumbKey)
+	})
+
+	t.Run("Content does not match the stored type", func(t *testing.T) {
+		_, err := service.ProcessUploadedPhoto(key, "image/jpeg")
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+	})
+
+	t.Run("Not uploaded", func(t *testing.T) {
+		_, err := service.ProcessUploadedPhoto("animals/3/uploads/b.png", "image/png")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
@@ -0,0 +1,103 @@
+package ranking
+
+import (
+	"math"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+// Session is a replayed slice of a user's history: the profile learned from
+// earlier swipes and the animals the user swiped on afterwards.
+type Session struct {
+	Profile    *Profile
+	Now        time.Time
+	Candidates []models.Animal
+	Liked      map[uint]bool
+}
+
+type Metrics struct {
+	Sessions    int
+	AUC         float64
+	PrecisionAt float64
+	NDCGAt      float64
+	K           int
+}
+
+// Evaluate ranks every session and averages how well liked animals are placed
+// ahead of passed ones. Sessions without both a like and a pass are skipped.
+func Evaluate(r *Ranker, sessions []Session, k int) Metrics {
+	m := Metrics{K: k}
+	for _, s := range sessions {
+		likes := 0
+		for _, a := range s.Candidates {
+			if s.Liked[a.ID] {
+				likes++
+			}
+		}
+		if likes == 0 || likes == len(s.Candidates) {
+			continue
+		}
+
+		ranked := r.Rank(s.Profile, s.Candidates, s.Now)
+		m.Sessions++
+		m.AUC += auc(ranked, s.Liked)
+		m.PrecisionAt += precisionAt(ranked, s.Liked, k)
+		m.NDCGAt += ndcgAt(ranked, s.Liked, k, likes)
+	}
+
+	if m.Sessions > 0 {
+		m.AUC /= float64(m.Sessions)
+		m.PrecisionAt /= float64(m.Sessions)
+		m.NDCGAt /= float64(m.Sessions)
+	}
+	return m
+}
+
+// auc is the share of (liked, passed) pairs in which the liked animal is ranked first.
+func auc(ranked []models.Animal, liked map[uint]bool) float64 {
+	var likes, correct, passedBelow int
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if liked[ranked[i].ID] {
+			likes++
+			correct += passedBelow
+		} else {
+			passedBelow++
+		}
+	}
+	pairs := likes * (len(ranked) - likes)
+	if pairs == 0 {
+		return 0
+	}
+	return float64(correct) / float64(pairs)
+}
+
+func precisionAt(ranked []models.Animal, liked map[uint]bool, k int) float64 {
+	n := min(k, len(ranked))
+	if n == 0 {
+		return 0
+	}
+	hits := 0
+	for _, a := range ranked[:n] {
+		if liked[a.ID] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(n)
+}
+
+func ndcgAt(ranked []models.Animal, liked map[uint]bool, k, likes int) float64 {
+	var dcg, ideal float64
+	for i := 0; i < min(k, len(ranked)); i++ {
+		if liked[ranked[i].ID] {
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+	}
+	for i := 0; i < min(k, likes); i++ {
+		ideal += 1 / math.Log2(float64(i+2))
+	}
+	if ideal == 0 {
+		return 0
+	}
+	return dcg / ideal
+}
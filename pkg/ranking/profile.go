@@ -0,0 +1,92 @@
+package ranking
+
+import (
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+// Signal is a past swipe together with the attributes of the swiped animal.
+type Signal struct {
+	AnimalType string
+	Gender     string
+	Age        float32
+	Liked      bool
+}
+
+type counts struct {
+	likes  int
+	passes int
+}
+
+// Profile is what the ranking knows about a user: their explicit settings
+// and the preferences learned from their swipes.
+type Profile struct {
+	Settings models.UserSettings
+	Swipes   int
+
+	types   map[string]*counts
+	genders map[string]*counts
+	ages    map[string]*counts
+}
+
+func NewProfile(settings models.UserSettings, signals []Signal) *Profile {
+	p := &Profile{
+		Settings: settings,
+		types:    map[string]*counts{},
+		genders:  map[string]*counts{},
+		ages:     map[string]*counts{},
+	}
+	for _, s := range signals {
+		p.Add(s)
+	}
+	return p
+}
+
+// Add learns from one more swipe.
+func (p *Profile) Add(s Signal) {
+	p.Swipes++
+	record(p.types, s.AnimalType, s.Liked)
+	record(p.genders, s.Gender, s.Liked)
+	record(p.ages, AgeBucket(s.Age), s.Liked)
+}
+
+// TypeLikeRate, GenderLikeRate and AgeLikeRate return the smoothed share of
+// likes among swipes on animals with the attribute, 0.5 when nothing is known.
+func (p *Profile) TypeLikeRate(animalType string) float64 { return likeRate(p.types, animalType) }
+func (p *Profile) GenderLikeRate(gender string) float64   { return likeRate(p.genders, gender) }
+func (p *Profile) AgeLikeRate(age float32) float64        { return likeRate(p.ages, AgeBucket(age)) }
+
+// AgeBucket groups ages into life stages.
+func AgeBucket(age float32) string {
+	switch {
+	case age < 1:
+		return "baby"
+	case age < 3:
+		return "young"
+	case age < 8:
+		return "adult"
+	default:
+		return "senior"
+	}
+}
+
+func record(m map[string]*counts, key string, liked bool) {
+	c, ok := m[key]
+	if !ok {
+		c = &counts{}
+		m[key] = c
+	}
+	if liked {
+		c.likes++
+	} else {
+		c.passes++
+	}
+}
+
+// likeRate applies Laplace smoothing so that a single swipe does not dominate.
+func likeRate(m map[string]*counts, key string) float64 {
+	c, ok := m[key]
+	if !ok {
+		return 0.5
+	}
+	return float64(c.likes+1) / float64(c.likes+c.passes+2)
+}
@@ -0,0 +1,68 @@
+package ranking
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+type WeightedScorer struct {
+	Scorer Scorer
+	Weight float64
+}
+
+// Ranker orders feed candidates by the weighted sum of its scorers.
+type Ranker struct {
+	scorers []WeightedScorer
+}
+
+func NewRanker(scorers ...WeightedScorer) *Ranker {
+	return &Ranker{scorers: scorers}
+}
+
+// NewDefaultRanker combines every signal the feed knows about.
+func NewDefaultRanker() *Ranker {
+	return NewRanker(
+		WeightedScorer{Scorer: SettingsScorer{}, Weight: 1},
+		WeightedScorer{Scorer: PreferenceScorer{}, Weight: 2},
+		WeightedScorer{Scorer: FreshnessScorer{HalfLife: 14 * 24 * time.Hour}, Weight: 1},
+		WeightedScorer{Scorer: DistanceScorer{ScaleKm: 25}, Weight: 1},
+	)
+}
+
+func (r *Ranker) Score(profile *Profile, candidate *models.Animal, now time.Time) float64 {
+	var score float64
+	for _, s := range r.scorers {
+		score += s.Weight * s.Scorer.Score(profile, candidate, now)
+	}
+	return score
+}
+
+// Rank returns the candidates from best to worst. Candidates with equal
+// scores keep their original order.
+func (r *Ranker) Rank(profile *Profile, candidates []models.Animal, now time.Time) []models.Animal {
+	scores := make([]float64, len(candidates))
+	order := make([]int, len(candidates))
+	for i := range candidates {
+		scores[i] = r.Score(profile, &candidates[i], now)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+
+	ranked := make([]models.Animal, 0, len(candidates))
+	for _, i := range order {
+		ranked = append(ranked, candidates[i])
+	}
+	return ranked
+}
+
+// Strategies are the rankers compared by the offline evaluation.
+func Strategies() map[string]*Ranker {
+	return map[string]*Ranker{
+		"newest":     NewRanker(WeightedScorer{Scorer: FreshnessScorer{HalfLife: 14 * 24 * time.Hour}, Weight: 1}),
+		"settings":   NewRanker(WeightedScorer{Scorer: SettingsScorer{}, Weight: 1}),
+		"preference": NewRanker(WeightedScorer{Scorer: PreferenceScorer{}, Weight: 1}),
+		"default":    NewDefaultRanker(),
+	}
+}
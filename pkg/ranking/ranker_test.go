@@ -0,0 +1,128 @@
+package ranking_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/ranking"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestProfile_LikeRates(t *testing.T) {
+	profile := ranking.NewProfile(models.UserSettings{}, []ranking.Signal{
+		{AnimalType: "cat", Gender: "FEMALE", Age: 2, Liked: true},
+		{AnimalType: "cat", Gender: "MALE", Age: 2, Liked: true},
+		{AnimalType: "dog", Gender: "MALE", Age: 9, Liked: false},
+	})
+
+	assert.Equal(t, 3, profile.Swipes)
+	assert.InDelta(t, 0.75, profile.TypeLikeRate("cat"), 1e-9)
+	assert.InDelta(t, 1.0/3, profile.TypeLikeRate("dog"), 1e-9)
+	assert.InDelta(t, 0.5, profile.TypeLikeRate("parrot"), 1e-9)
+	assert.InDelta(t, 0.5, profile.GenderLikeRate("MALE"), 1e-9)
+	assert.InDelta(t, 1.0/3, profile.AgeLikeRate(12), 1e-9)
+}
+
+func TestSettingsScorer(t *testing.T) {
+	catType := "cat"
+	profile := ranking.NewProfile(models.UserSettings{
+		Type:   &catType,
+		MinAge: 0,
+		MaxAge: 5,
+		Gender: pq.StringArray{"FEMALE"},
+	}, nil)
+
+	scorer := ranking.SettingsScorer{}
+	now := time.Now()
+
+	assert.Equal(t, 1.0, scorer.Score(profile, &models.Animal{Type: "Cat", Age: 3, Gender: "FEMALE"}, now))
+	assert.InDelta(t, 2.0/3, scorer.Score(profile, &models.Animal{Type: "cat", Age: 7, Gender: "FEMALE"}, now), 1e-9)
+	assert.Equal(t, 0.0, scorer.Score(profile, &models.Animal{Type: "dog", Age: 9, Gender: "MALE"}, now))
+	assert.Equal(t, 0.5, scorer.Score(ranking.NewProfile(models.UserSettings{}, nil), &models.Animal{}, now))
+}
+
+func TestFreshnessAndDistanceScorers(t *testing.T) {
+	now := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	freshness := ranking.FreshnessScorer{HalfLife: 7 * 24 * time.Hour}
+
+	fresh := models.Animal{}
+	fresh.CreatedAt = now
+	week := models.Animal{}
+	week.CreatedAt = now.Add(-7 * 24 * time.Hour)
+	relisted := week
+	relisted.ListingChangedAt = &now
+
+	assert.Equal(t, 1.0, freshness.Score(nil, &fresh, now))
+	assert.InDelta(t, 0.5, freshness.Score(nil, &week, now), 1e-9)
+	assert.Equal(t, 1.0, freshness.Score(nil, &relisted, now))
+
+	distance := ranking.DistanceScorer{ScaleKm: 10}
+	near, far := 0.0, 10.0
+	assert.Equal(t, 1.0, distance.Score(nil, &models.Animal{Distance: &near}, now))
+	assert.Equal(t, 0.5, distance.Score(nil, &models.Animal{Distance: &far}, now))
+	assert.Equal(t, 0.5, distance.Score(nil, &models.Animal{}, now))
+}
+
+func TestRanker_Rank(t *testing.T) {
+	profile := ranking.NewProfile(models.UserSettings{}, []ranking.Signal{
+		{AnimalType: "cat", Liked: true},
+		{AnimalType: "dog", Liked: false},
+	})
+	ranker := ranking.NewRanker(ranking.WeightedScorer{Scorer: ranking.PreferenceScorer{}, Weight: 1})
+
+	candidates := []models.Animal{
+		{Name: "Rex", Type: "dog"},
+		{Name: "Tom", Type: "cat"},
+		{Name: "Polly", Type: "parrot"},
+		{Name: "Kitty", Type: "cat"},
+	}
+
+	ranked := ranker.Rank(profile, candidates, time.Now())
+
+	names := []string{}
+	for _, a := range ranked {
+		names = append(names, a.Name)
+	}
+	assert.Equal(t, []string{"Tom", "Kitty", "Polly", "Rex"}, names)
+}
+
+func TestEvaluate(t *testing.T) {
+	profile := ranking.NewProfile(models.UserSettings{}, []ranking.Signal{
+		{AnimalType: "cat", Liked: true},
+		{AnimalType: "dog", Liked: false},
+	})
+	session := ranking.Session{
+		Profile: profile,
+		Now:     time.Now(),
+		Candidates: []models.Animal{
+			{Model: gormModel(1), Type: "dog"},
+			{Model: gormModel(2), Type: "cat"},
+		},
+		Liked: map[uint]bool{1: false, 2: true},
+	}
+	skipped := ranking.Session{
+		Profile:    profile,
+		Candidates: []models.Animal{{Model: gormModel(3), Type: "cat"}},
+		Liked:      map[uint]bool{3: true},
+	}
+
+	preference := ranking.NewRanker(ranking.WeightedScorer{Scorer: ranking.PreferenceScorer{}, Weight: 1})
+	m := ranking.Evaluate(preference, []ranking.Session{session, skipped}, 1)
+
+	assert.Equal(t, 1, m.Sessions)
+	assert.Equal(t, 1.0, m.AUC)
+	assert.Equal(t, 1.0, m.PrecisionAt)
+	assert.Equal(t, 1.0, m.NDCGAt)
+
+	// With no scorers candidates keep their order, which puts the pass first.
+	m = ranking.Evaluate(ranking.NewRanker(), []ranking.Session{session}, 1)
+	assert.Equal(t, 0.0, m.AUC)
+	assert.Equal(t, 0.0, m.PrecisionAt)
+}
+
+func gormModel(id uint) gorm.Model {
+	return gorm.Model{ID: id}
+}
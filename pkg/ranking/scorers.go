@@ -0,0 +1,91 @@
+package ranking
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+// Scorer rates how well a candidate suits the user. Scores are in [0, 1].
+type Scorer interface {
+	Score(profile *Profile, candidate *models.Animal, now time.Time) float64
+}
+
+// SettingsScorer rewards candidates that match the user's explicit settings.
+type SettingsScorer struct{}
+
+func (SettingsScorer) Score(profile *Profile, a *models.Animal, _ time.Time) float64 {
+	s := profile.Settings
+	matched, total := 0, 0
+	check := func(ok bool) {
+		total++
+		if ok {
+			matched++
+		}
+	}
+
+	if s.Type != nil && *s.Type != "" {
+		check(strings.EqualFold(*s.Type, a.Type))
+	}
+	if s.MaxAge > 0 {
+		check(a.Age >= float32(s.MinAge) && a.Age <= float32(s.MaxAge))
+	}
+	if len(s.Gender) > 0 {
+		ok := false
+		for _, g := range s.Gender {
+			ok = ok || strings.EqualFold(g, a.Gender)
+		}
+		check(ok)
+	}
+	if s.Vaccinated {
+		check(a.Vaccinated)
+	}
+	if s.Sterilized {
+		check(a.Sterilized)
+	}
+
+	if total == 0 {
+		return 0.5
+	}
+	return float64(matched) / float64(total)
+}
+
+// PreferenceScorer rewards candidates resembling animals the user liked
+// before and penalizes those resembling animals they passed on.
+type PreferenceScorer struct{}
+
+func (PreferenceScorer) Score(profile *Profile, a *models.Animal, _ time.Time) float64 {
+	return (profile.TypeLikeRate(a.Type) + profile.GenderLikeRate(a.Gender) + profile.AgeLikeRate(a.Age)) / 3
+}
+
+// FreshnessScorer favors recently listed animals. The score halves every HalfLife.
+type FreshnessScorer struct {
+	HalfLife time.Duration
+}
+
+func (f FreshnessScorer) Score(_ *Profile, a *models.Animal, now time.Time) float64 {
+	listed := a.CreatedAt
+	if a.ListingChangedAt != nil && a.ListingChangedAt.After(listed) {
+		listed = *a.ListingChangedAt
+	}
+	age := now.Sub(listed)
+	if age <= 0 || f.HalfLife <= 0 {
+		return 1
+	}
+	return math.Exp2(-age.Hours() / f.HalfLife.Hours())
+}
+
+// DistanceScorer favors animals close to the user. The score is 0.5 at Scale
+// kilometers and neutral when the distance is unknown.
+type DistanceScorer struct {
+	ScaleKm float64
+}
+
+func (d DistanceScorer) Score(_ *Profile, a *models.Animal, _ time.Time) float64 {
+	if a.Distance == nil || d.ScaleKm <= 0 {
+		return 0.5
+	}
+	return 1 / (1 + *a.Distance/d.ScaleKm)
+}
@@ -0,0 +1,16 @@
+package retry
+
+import "time"
+
+// Backoff returns how long to wait before retrying an operation that failed
+// for the given time, doubling from base up to max.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -0,0 +1,19 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := time.Minute, 30*time.Minute
+
+	assert.Equal(t, time.Minute, retry.Backoff(1, base, max))
+	assert.Equal(t, 2*time.Minute, retry.Backoff(2, base, max))
+	assert.Equal(t, 16*time.Minute, retry.Backoff(5, base, max))
+	assert.Equal(t, max, retry.Backoff(6, base, max))
+	assert.Equal(t, max, retry.Backoff(40, base, max))
+}
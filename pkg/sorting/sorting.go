@@ -0,0 +1,98 @@
+package sorting
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/constants"
+	"github.com/gin-gonic/gin"
+)
+
+type Order string
+
+const (
+	Newest    Order = "newest"
+	Oldest    Order = "oldest"
+	AgeAsc    Order = "age_asc"
+	AgeDesc   Order = "age_desc"
+	Name      Order = "name"
+	Distance  Order = "distance"
+	Relevance Order = "relevance"
+)
+
+var orders = map[Order]bool{
+	Newest:    true,
+	Oldest:    true,
+	AgeAsc:    true,
+	AgeDesc:   true,
+	Name:      true,
+	Distance:  true,
+	Relevance: true,
+}
+
+type Params struct {
+	Order     Order
+	Latitude  *float64
+	Longitude *float64
+}
+
+// HasLocation reports whether the request carries coordinates to measure distance from.
+func (p Params) HasLocation() bool {
+	return p.Latitude != nil && p.Longitude != nil
+}
+
+// GetSortQueryParams reads the sort order and reference location from the query.
+// Relevance is the default for search requests and newest for everything else.
+func GetSortQueryParams(c *gin.Context) (Params, error) {
+	params := Params{Order: Newest}
+	hasSearch := strings.TrimSpace(c.Query(constants.SearchParam)) != ""
+	if hasSearch {
+		params.Order = Relevance
+	}
+
+	if sort := c.Query(constants.SortParam); sort != "" {
+		order := Order(strings.ToLower(sort))
+		if !orders[order] {
+			return Params{}, fmt.Errorf("unsupported sort order %q", sort)
+		}
+		params.Order = order
+	}
+
+	lat, err := parseCoordinate(c.Query(constants.LatitudeParam), 90)
+	if err != nil {
+		return Params{}, fmt.Errorf("invalid latitude: %w", err)
+	}
+	lng, err := parseCoordinate(c.Query(constants.LongitudeParam), 180)
+	if err != nil {
+		return Params{}, fmt.Errorf("invalid longitude: %w", err)
+	}
+	if (lat == nil) != (lng == nil) {
+		return Params{}, errors.New("latitude and longitude must be provided together")
+	}
+	params.Latitude, params.Longitude = lat, lng
+
+	if params.Order == Relevance && !hasSearch {
+		return Params{}, errors.New("relevance sort requires a search query")
+	}
+	if params.Order == Distance && !params.HasLocation() {
+		return Params{}, errors.New("distance sort requires latitude and longitude")
+	}
+
+	return params, nil
+}
+
+func parseCoordinate(value string, limit float64) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if v < -limit || v > limit {
+		return nil, fmt.Errorf("%v is out of range", v)
+	}
+	return &v, nil
+}
@@ -0,0 +1,62 @@
+package sorting_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/sorting"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSortQueryParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedOrder sorting.Order
+		expectedErr   bool
+	}{
+		{name: "default sort", query: "/", expectedOrder: sorting.Newest},
+		{name: "default sort with search", query: "/?q=husky", expectedOrder: sorting.Relevance},
+		{name: "explicit sort", query: "/?sort=age_desc", expectedOrder: sorting.AgeDesc},
+		{name: "sort is case insensitive", query: "/?sort=NAME", expectedOrder: sorting.Name},
+		{name: "distance with location", query: "/?sort=distance&lat=50.45&lng=30.52", expectedOrder: sorting.Distance},
+		{name: "unsupported sort", query: "/?sort=popularity", expectedErr: true},
+		{name: "relevance without search", query: "/?sort=relevance", expectedErr: true},
+		{name: "distance without location", query: "/?sort=distance", expectedErr: true},
+		{name: "latitude without longitude", query: "/?lat=50.45", expectedErr: true},
+		{name: "latitude out of range", query: "/?lat=91&lng=30.52", expectedErr: true},
+		{name: "invalid longitude", query: "/?lat=50.45&lng=east", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest("GET", tt.query, nil)
+
+			params, err := sorting.GetSortQueryParams(c)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOrder, params.Order)
+		})
+	}
+
+	t.Run("location is parsed", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("GET", "/?lat=50.45&lng=-30.52", nil)
+
+		params, err := sorting.GetSortQueryParams(c)
+
+		assert.NoError(t, err)
+		assert.True(t, params.HasLocation())
+		assert.Equal(t, 50.45, *params.Latitude)
+		assert.Equal(t, -30.52, *params.Longitude)
+	})
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// contentTypes covers the extensions the mime package may not know.
+var contentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+	".heic": "image/heic",
+}
+
+// LocalStore keeps objects as files under a directory, for local
+// development. The files are served by the API at baseURL, which also
+// accepts the presigned uploads.
+type LocalStore struct {
+	dir     string
+	baseURL string
+	// secret signs presigned uploads. It changes on every start, which only
+	// invalidates pending uploads.
+	secret []byte
+}
+
+func NewLocalStore(dir, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &LocalStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), secret: secret}, nil
+}
+
+// Path returns the file of the object, refusing keys that would escape the
+// directory.
+func (s *LocalStore) Path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object to a temporary file first, so that readers never
+// see it half written. The content type is implied by the key's extension.
+func (s *LocalStore) Put(key string, body io.Reader, contentType string) (Object, error) {
+	name, err := s.Path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return Object{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return Object{}, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return Object{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return Object{}, err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return Object{}, err
+	}
+	return s.Head(key)
+}
+
+func (s *LocalStore) Get(key string) (io.ReadCloser, error) {
+	name, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *LocalStore) Head(key string) (Object, error) {
+	name, err := s.Path(key)
+	if err != nil {
+		return Object{}, err
+	}
+	info, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && info.IsDir() {
+		return Object{}, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, err
+	}
+	return Object{Key: key, URL: s.baseURL + "/" + key, Size: info.Size(), ContentType: ContentType(key), ModTime: info.ModTime()}, nil
+}
+
+// List walks the directory. Files of uploads that were interrupted are
+// listed too, as nothing else removes them.
+func (s *LocalStore) List(prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(s.dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.dir, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, URL: s.baseURL + "/" + key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return objects, err
+}
+
+func (s *LocalStore) Delete(key string) error {
+	name, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PresignPut returns a PUT to the object's URL, signed with its type, size
+// and expiry. VerifyPut checks the signature when the request comes in.
+func (s *LocalStore) PresignPut(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error) {
+	if _, err := s.Path(key); err != nil {
+		return models.PresignedUpload{}, err
+	}
+	expiresAt := time.Now().Add(expires)
+
+	query := url.Values{}
+	query.Set("size", strconv.FormatInt(size, 10))
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.sign(key, contentType, size, expiresAt.Unix()))
+	return models.PresignedUpload{
+		Key:       key,
+		Method:    http.MethodPut,
+		URL:       s.baseURL + "/" + key + "?" + query.Encode(),
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyPut checks that the upload was presigned for the key and content
+// type and has not expired. It returns the size the upload must have.
+func (s *LocalStore) VerifyPut(key, contentType string, query url.Values) (int64, error) {
+	size, err := strconv.ParseInt(query.Get("size"), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return 0, ErrInvalidSignature
+	}
+	signature := s.sign(key, contentType, size, expires)
+	if !hmac.Equal([]byte(signature), []byte(query.Get("signature"))) {
+		return 0, ErrInvalidSignature
+	}
+	return size, nil
+}
+
+func (s *LocalStore) sign(key, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%d", key, contentType, size, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ContentType returns the type of the object as implied by its extension.
+func ContentType(key string) string {
+	ext := strings.ToLower(path.Ext(key))
+	if contentType, ok := contentTypes[ext]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
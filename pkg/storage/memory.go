@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	modTime     time.Time
+}
+
+// MemoryStore keeps objects in memory. It is meant for tests.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+	baseURL string
+}
+
+func NewMemoryStore(baseURL string) *MemoryStore {
+	return &MemoryStore{objects: make(map[string]memoryObject), baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (s *MemoryStore) Put(key string, body io.Reader, contentType string) (Object, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return Object{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = memoryObject{data: data, contentType: contentType, modTime: time.Now()}
+	return s.object(key, s.objects[key]), nil
+}
+
+func (s *MemoryStore) Get(key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(o.data)), nil
+}
+
+func (s *MemoryStore) Head(key string) (Object, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.objects[key]
+	if !ok {
+		return Object{}, ErrNotFound
+	}
+	return s.object(key, o), nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStore) List(prefix string) ([]Object, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var objects []Object
+	for key, o := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			object := s.object(key, o)
+			object.ContentType = ""
+			objects = append(objects, object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// PresignPut returns a request to the object's URL. Nothing serves it; tests
+// store the object with Put instead.
+func (s *MemoryStore) PresignPut(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error) {
+	return models.PresignedUpload{
+		Key:       key,
+		Method:    http.MethodPut,
+		URL:       s.baseURL + "/" + key,
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
+
+func (s *MemoryStore) object(key string, o memoryObject) Object {
+	return Object{Key: key, URL: s.baseURL + "/" + key, Size: int64(len(o.data)), ContentType: o.contentType, ModTime: o.modTime}
+}
@@ -0,0 +1,42 @@
+// Package storage stores uploaded files in a blob store: S3 or a compatible
+// service, the local disk or memory.
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/models"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object.
+type Object struct {
+	Key         string
+	URL         string
+	Size        int64
+	ContentType string
+	// ModTime is when the object was last stored.
+	ModTime time.Time
+}
+
+// BlobStoreI stores publicly readable objects by key.
+type BlobStoreI interface {
+	Put(key string, body io.Reader, contentType string) (Object, error)
+	// Get returns ErrNotFound when there is no object under the key.
+	Get(key string) (io.ReadCloser, error)
+	// Head returns ErrNotFound when there is no object under the key.
+	Head(key string) (Object, error)
+	Delete(key string) error
+	// List returns the objects whose keys start with the prefix, ordered by
+	// key. Their content types are not known.
+	List(prefix string) ([]Object, error)
+	// PresignPut returns a request the client can store an object of
+	// exactly that type and size with, without going through the API.
+	PresignPut(key, contentType string, size int64, expires time.Duration) (models.PresignedUpload, error)
+}
@@ -0,0 +1,115 @@
+package storage_test
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlobStores(t *testing.T) {
+	local, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files/")
+	assert.NoError(t, err)
+
+	for name, store := range map[string]storage.BlobStoreI{
+		"memory": storage.NewMemoryStore("memory://"),
+		"local":  local,
+	} {
+		t.Run(name, func(t *testing.T) {
+			object, err := store.Put("animals/3/luna.jpg", strings.NewReader("photo"), "image/jpeg")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(5), object.Size)
+			assert.True(t, strings.HasSuffix(object.URL, "/animals/3/luna.jpg"))
+
+			head, err := store.Head("animals/3/luna.jpg")
+			assert.NoError(t, err)
+			assert.Equal(t, object, head)
+
+			body, err := store.Get("animals/3/luna.jpg")
+			assert.NoError(t, err)
+			data, _ := io.ReadAll(body)
+			body.Close()
+			assert.Equal(t, "photo", string(data))
+
+			assert.NoError(t, store.Delete("animals/3/luna.jpg"))
+			assert.NoError(t, store.Delete("animals/3/luna.jpg"))
+			_, err = store.Head("animals/3/luna.jpg")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+			_, err = store.Get("animals/3/luna.jpg")
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		t.Run(name+" lists by prefix", func(t *testing.T) {
+			for _, key := range []string{"animals/4/b.jpg", "animals/4/a.jpg", "animals/40/c.jpg", "message_1_full.jpg"} {
+				_, err := store.Put(key, strings.NewReader("photo"), "image/jpeg")
+				assert.NoError(t, err)
+			}
+
+			objects, err := store.List("animals/4/")
+			assert.NoError(t, err)
+			assert.Len(t, objects, 2)
+			for i, key := range []string{"animals/4/a.jpg", "animals/4/b.jpg"} {
+				assert.Equal(t, key, objects[i].Key)
+				assert.Equal(t, int64(5), objects[i].Size)
+				assert.WithinDuration(t, time.Now(), objects[i].ModTime, time.Minute)
+			}
+
+			objects, err = store.List("")
+			assert.NoError(t, err)
+			assert.Len(t, objects, 4)
+		})
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost:8080/files")
+	assert.NoError(t, err)
+
+	t.Run("Keys stay inside the directory", func(t *testing.T) {
+		for _, key := range []string{"../secret", "/etc/passwd", "a/../../b", ""} {
+			_, err := store.Put(key, strings.NewReader("x"), "image/jpeg")
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		}
+	})
+
+	t.Run("Presigned uploads", func(t *testing.T) {
+		upload, err := store.PresignPut("animals/3/uploads/a.png", "image/png", 1024, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, "PUT", upload.Method)
+		assert.Equal(t, "image/png", upload.Headers["Content-Type"])
+		u, err := url.Parse(upload.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/files/animals/3/uploads/a.png", u.Path)
+
+		size, err := store.VerifyPut("animals/3/uploads/a.png", "image/png", u.Query())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1024), size)
+
+		_, err = store.VerifyPut("animals/3/uploads/a.png", "image/jpeg", u.Query())
+		assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+		_, err = store.VerifyPut("animals/4/uploads/a.png", "image/png", u.Query())
+		assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+		query := u.Query()
+		query.Set("size", "4096")
+		_, err = store.VerifyPut("animals/3/uploads/a.png", "image/png", query)
+		assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+	})
+
+	t.Run("Expired uploads", func(t *testing.T) {
+		upload, err := store.PresignPut("a.png", "image/png", 1024, -time.Minute)
+		assert.NoError(t, err)
+		u, _ := url.Parse(upload.URL)
+		_, err = store.VerifyPut("a.png", "image/png", u.Query())
+		assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+	})
+
+	t.Run("Content type follows the extension", func(t *testing.T) {
+		object, err := store.Put("b.heic", strings.NewReader("x"), "image/heic")
+		assert.NoError(t, err)
+		assert.Equal(t, "image/heic", object.ContentType)
+	})
+}
@@ -0,0 +1,41 @@
+package uploads
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+)
+
+// Fetch downloads the file at the URL, failing with ErrFileTooLarge beyond
+// maxSize bytes. URLs given by users are fetched with a client from
+// netguard.NewPublicClient.
+func Fetch(client *http.Client, rawURL string, maxSize int64) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" {
+		return nil, netguard.ErrUnsupportedURL
+	}
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with %s", u.Host, resp.Status)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,44 @@
+package uploads_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/netguard"
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photo.jpg":
+			w.Write([]byte("photo"))
+		case "/large.jpg":
+			w.Write([]byte(strings.Repeat("x", 100)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	data, err := uploads.Fetch(server.Client(), server.URL+"/photo.jpg", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "photo", string(data))
+
+	_, err = uploads.Fetch(server.Client(), server.URL+"/large.jpg", 10)
+	assert.ErrorIs(t, err, uploads.ErrFileTooLarge)
+
+	_, err = uploads.Fetch(server.Client(), server.URL+"/missing.jpg", 10)
+	assert.ErrorContains(t, err, "404")
+
+	_, err = uploads.Fetch(server.Client(), "file:///etc/passwd", 10)
+	assert.ErrorIs(t, err, netguard.ErrUnsupportedURL)
+
+	// The test server listens on a loopback address.
+	_, err = uploads.Fetch(netguard.NewPublicClient(time.Second), server.URL+"/photo.jpg", 10)
+	assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+}
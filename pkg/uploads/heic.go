@@ -0,0 +1,29 @@
+package uploads
+
+import "encoding/binary"
+
+var heicBrands = map[string]bool{
+	"heic": true, "heix": true, "heim": true, "heis": true, "hevc": true, "hevx": true,
+}
+
+// isHEIC reports whether the file starts with an ISO BMFF "ftyp" box naming
+// a HEIC brand, either as the major or a compatible brand.
+func isHEIC(data []byte) bool {
+	if len(data) < 16 || string(data[4:8]) != "ftyp" {
+		return false
+	}
+	size := int(binary.BigEndian.Uint32(data))
+	if size < 16 || size > len(data) {
+		return false
+	}
+	if heicBrands[string(data[8:12])] {
+		return true
+	}
+	// Compatible brands follow the major brand and its minor version.
+	for i := 16; i+4 <= size; i += 4 {
+		if heicBrands[string(data[i:i+4])] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package uploads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+var (
+	ErrFileTooLarge   = errors.New("file is too large")
+	ErrTooManyFiles   = errors.New("too many files")
+	ErrUnexpectedPart = errors.New("unexpected form part")
+	ErrMissingPart    = errors.New("missing form part")
+)
+
+// maxFieldSize bounds the non-file form fields.
+const maxFieldSize = 64 << 10
+
+// FileReaderI iterates over uploaded files. Next returns io.EOF after the
+// last file.
+type FileReaderI interface {
+	Next() (*File, error)
+}
+
+// File is an uploaded file. Its content must be read before the next file
+// is requested, as it is streamed from the request body.
+type File struct {
+	Field    string
+	Filename string
+
+	content io.Reader
+	left    int64
+	err     error
+}
+
+// Read reads the file, failing with ErrFileTooLarge once it grows past the
+// per-file limit.
+func (f *File) Read(p []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	if int64(len(p)) > f.left+1 {
+		p = p[:f.left+1]
+	}
+	n, err := f.content.Read(p)
+	f.left -= int64(n)
+	if f.left < 0 {
+		n--
+		err = ErrFileTooLarge
+	}
+	if err != nil && err != io.EOF {
+		f.err = err
+	}
+	return n, err
+}
+
+// Err returns the error that stopped reading the file, if any. Storage
+// clients tend to wrap the errors of the readers they consume; Err tells
+// a rejected file apart from a failed upload.
+func (f *File) Err() error {
+	return f.err
+}
+
+// MultipartReader streams a multipart/form-data request without buffering
+// the files in memory or on disk.
+type MultipartReader struct {
+	reader      *multipart.Reader
+	maxFileSize int64
+	maxFiles    int
+	files       int
+}
+
+func NewMultipartReader(r *http.Request, maxFileSize int64, maxFiles int) (*MultipartReader, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	return &MultipartReader{reader: reader, maxFileSize: maxFileSize, maxFiles: maxFiles}, nil
+}
+
+// ReadJSON decodes the next part, which must be the named field, into v.
+// Fields describing the files have to come before them.
+func (r *MultipartReader) ReadJSON(field string, v interface{}) error {
+	part, err := r.reader.NextPart()
+	if err == io.EOF {
+		return fmt.Errorf("%w: %s", ErrMissingPart, field)
+	}
+	if err != nil {
+		return err
+	}
+	if part.FormName() != field || part.FileName() != "" {
+		return fmt.Errorf("%w: %q must come first", ErrUnexpectedPart, field)
+	}
+	return json.NewDecoder(io.LimitReader(part, maxFieldSize)).Decode(v)
+}
+
+func (r *MultipartReader) Next() (*File, error) {
+	part, err := r.reader.NextPart()
+	if err != nil {
+		return nil, err
+	}
+	if part.FileName() == "" {
+		return nil, fmt.Errorf("%w: %q is not a file", ErrUnexpectedPart, part.FormName())
+	}
+
+	r.files++
+	if r.files > r.maxFiles {
+		return nil, ErrTooManyFiles
+	}
+	return &File{Field: part.FormName(), Filename: part.FileName(), content: part, left: r.maxFileSize}, nil
+}
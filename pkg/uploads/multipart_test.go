@@ -0,0 +1,117 @@
+package uploads_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/stretchr/testify/assert"
+)
+
+type part struct {
+	field, filename, content string
+}
+
+func newRequest(t *testing.T, parts ...part) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, p := range parts {
+		var w io.Writer
+		var err error
+		if p.filename == "" {
+			w, err = writer.CreateFormField(p.field)
+		} else {
+			w, err = writer.CreateFormFile(p.field, p.filename)
+		}
+		assert.NoError(t, err)
+		io.WriteString(w, p.content)
+	}
+	assert.NoError(t, writer.Close())
+
+	r, _ := http.NewRequest("POST", "/animal", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestMultipartReader(t *testing.T) {
+	t.Run("JSON then files", func(t *testing.T) {
+		r := newRequest(t,
+			part{field: "animal", content: `{"name":"Luna"}`},
+			part{field: "image", filename: "luna.jpg", content: "image"},
+			part{field: "photos", filename: "1.jpg", content: "photo"},
+		)
+		reader, err := uploads.NewMultipartReader(r, 10, 5)
+		assert.NoError(t, err)
+
+		var animal struct{ Name string }
+		assert.NoError(t, reader.ReadJSON("animal", &animal))
+		assert.Equal(t, "Luna", animal.Name)
+
+		file, err := reader.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, "image", file.Field)
+		assert.Equal(t, "luna.jpg", file.Filename)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "image", string(content))
+
+		file, err = reader.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, "photos", file.Field)
+
+		_, err = reader.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Files before the JSON", func(t *testing.T) {
+		r := newRequest(t, part{field: "image", filename: "luna.jpg", content: "image"})
+		reader, err := uploads.NewMultipartReader(r, 10, 5)
+		assert.NoError(t, err)
+
+		var animal struct{ Name string }
+		assert.ErrorIs(t, reader.ReadJSON("animal", &animal), uploads.ErrUnexpectedPart)
+	})
+
+	t.Run("File too large", func(t *testing.T) {
+		r := newRequest(t, part{field: "image", filename: "luna.jpg", content: strings.Repeat("a", 11)})
+		reader, err := uploads.NewMultipartReader(r, 10, 5)
+		assert.NoError(t, err)
+
+		file, err := reader.Next()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.ErrorIs(t, err, uploads.ErrFileTooLarge)
+		assert.Len(t, content, 10)
+		assert.ErrorIs(t, file.Err(), uploads.ErrFileTooLarge)
+	})
+
+	t.Run("File at the limit", func(t *testing.T) {
+		r := newRequest(t, part{field: "image", filename: "luna.jpg", content: strings.Repeat("a", 10)})
+		reader, err := uploads.NewMultipartReader(r, 10, 5)
+		assert.NoError(t, err)
+
+		file, err := reader.Next()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Len(t, content, 10)
+	})
+
+	t.Run("Too many files", func(t *testing.T) {
+		r := newRequest(t,
+			part{field: "photos", filename: "1.jpg", content: "1"},
+			part{field: "photos", filename: "2.jpg", content: "2"},
+		)
+		reader, err := uploads.NewMultipartReader(r, 10, 1)
+		assert.NoError(t, err)
+
+		_, err = reader.Next()
+		assert.NoError(t, err)
+		_, err = reader.Next()
+		assert.ErrorIs(t, err, uploads.ErrTooManyFiles)
+	})
+}
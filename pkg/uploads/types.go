@@ -0,0 +1,120 @@
+package uploads
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/webp"
+)
+
+// acceptedTypes is told to clients whose file is of another type.
+const acceptedTypes = "convert the photo to JPEG, PNG or WebP"
+
+var (
+	ErrUnsupportedType = errors.New("unsupported file type")
+	ErrMalformedImage  = errors.New("file is not a valid image")
+	ErrImageDimensions = errors.New("image dimensions are out of bounds")
+)
+
+// imageExtensions maps the accepted image types to the extension they are
+// stored with. HEIC is not accepted, see DetectImageType.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ImageExtension returns the extension images of the content type are
+// stored with, or ErrUnsupportedType when the type is not accepted.
+func ImageExtension(contentType string) (string, error) {
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s, %s", ErrUnsupportedType, contentType, acceptedTypes)
+	}
+	return ext, nil
+}
+
+// ImageInfo describes a validated image.
+type ImageInfo struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// DetectImageType sniffs the type of the file from its content, ignoring
+// whatever the client claims it is.
+func DetectImageType(data []byte) (string, error) {
+	// HEIC is not supported: there is no decoder for it without cgo, and a
+	// photo that can not be decoded can be neither resized nor stripped of
+	// its location. Clients convert it, as iOS does when sharing photos. It
+	// is named so that they know to.
+	if isHEIC(data) {
+		return "", fmt.Errorf("%w: image/heic is not supported, %s", ErrUnsupportedType, acceptedTypes)
+	}
+	contentType := http.DetectContentType(data)
+	if _, err := ImageExtension(contentType); err != nil {
+		return "", err
+	}
+	return contentType, nil
+}
+
+// ValidateImage checks that the file is an accepted, well-formed image with
+// sides between minSide and maxSide pixels and at most maxPixels in all, and
+// returns it decoded.
+func ValidateImage(data []byte, minSide, maxSide, maxPixels int) (ImageInfo, image.Image, error) {
+	contentType, err := DetectImageType(data)
+	if err != nil {
+		return ImageInfo{}, nil, err
+	}
+
+	config, err := decodeConfig(contentType, bytes.NewReader(data))
+	if err != nil {
+		return ImageInfo{}, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+	}
+	width, height := config.Width, config.Height
+
+	if width < minSide || height < minSide || width > maxSide || height > maxSide {
+		return ImageInfo{}, nil, fmt.Errorf("%w: %dx%d, sides must be %d to %d pixels", ErrImageDimensions, width, height, minSide, maxSide)
+	}
+	if width*height > maxPixels {
+		return ImageInfo{}, nil, fmt.Errorf("%w: %dx%d, at most %d pixels", ErrImageDimensions, width, height, maxPixels)
+	}
+
+	// Only decode once the dimensions are known to be sane, as decoding
+	// allocates the whole bitmap.
+	img, err := decode(contentType, bytes.NewReader(data))
+	if err != nil {
+		return ImageInfo{}, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+	}
+
+	return ImageInfo{ContentType: contentType, Extension: imageExtensions[contentType], Width: width, Height: height}, img, nil
+}
+
+func decodeConfig(contentType string, r io.Reader) (image.Config, error) {
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.DecodeConfig(r)
+	case "image/png":
+		return png.DecodeConfig(r)
+	default:
+		return webp.DecodeConfig(r)
+	}
+}
+
+func decode(contentType string, r io.Reader) (image.Image, error) {
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Decode(r)
+	case "image/png":
+		return png.Decode(r)
+	default:
+		return webp.Decode(r)
+	}
+}
@@ -0,0 +1,87 @@
+package uploads_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/Kachyr/findyourpet/findyourpet-backend/pkg/uploads"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil))
+	return buf.Bytes()
+}
+
+// isoBox builds an ISO BMFF box.
+func isoBox(typ string, body ...[]byte) []byte {
+	content := bytes.Join(body, nil)
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header, uint32(8+len(content)))
+	copy(header[4:], typ)
+	return append(header, content...)
+}
+
+func TestValidateImage(t *testing.T) {
+	t.Run("PNG", func(t *testing.T) {
+		info, img, err := uploads.ValidateImage(encodePNG(t, 64, 48), 32, 1000, 1000*1000)
+		assert.NoError(t, err)
+		assert.Equal(t, uploads.ImageInfo{ContentType: "image/png", Extension: ".png", Width: 64, Height: 48}, info)
+		assert.Equal(t, image.Rect(0, 0, 64, 48), img.Bounds())
+	})
+
+	t.Run("JPEG", func(t *testing.T) {
+		info, _, err := uploads.ValidateImage(encodeJPEG(t, 64, 64), 32, 1000, 1000*1000)
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", info.ContentType)
+		assert.Equal(t, ".jpg", info.Extension)
+	})
+
+	t.Run("HEIC", func(t *testing.T) {
+		data := isoBox("ftyp", []byte("mif1\x00\x00\x00\x00mif1heic"))
+		_, _, err := uploads.ValidateImage(data, 32, 8192, 8192*8192)
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+		assert.Contains(t, err.Error(), "image/heic is not supported, convert the photo to JPEG")
+	})
+
+	t.Run("Not an image", func(t *testing.T) {
+		_, _, err := uploads.ValidateImage([]byte("%PDF-1.7\n"), 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+		assert.Contains(t, err.Error(), "application/pdf")
+
+		_, _, err = uploads.ValidateImage([]byte("MZ\x90\x00\x03\x00\x00\x00"), 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrUnsupportedType)
+	})
+
+	t.Run("Truncated image", func(t *testing.T) {
+		data := encodePNG(t, 64, 64)
+		_, _, err := uploads.ValidateImage(data[:len(data)/2], 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrMalformedImage)
+	})
+
+	t.Run("Fake WebP", func(t *testing.T) {
+		_, _, err := uploads.ValidateImage([]byte("RIFF\x10\x00\x00\x00WEBPVP8 \x04\x00\x00\x00junk"), 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrMalformedImage)
+	})
+
+	t.Run("Dimensions", func(t *testing.T) {
+		_, _, err := uploads.ValidateImage(encodePNG(t, 16, 64), 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrImageDimensions)
+		_, _, err = uploads.ValidateImage(encodePNG(t, 2000, 100), 32, 1000, 1000*1000)
+		assert.ErrorIs(t, err, uploads.ErrImageDimensions)
+		// Within the sides but over the pixels, caught before decoding.
+		_, _, err = uploads.ValidateImage(encodePNG(t, 900, 900), 32, 1000, 800*800)
+		assert.ErrorIs(t, err, uploads.ErrImageDimensions)
+	})
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	EventAnimalCreated = "animal.created"
+	EventAnimalLiked   = "animal.liked"
+	EventStatusChanged = "status.changed"
+	// EventApplicationSubmitted is reserved for adoption applications. It is
+	// not in EventTypes, so hooks can not subscribe to it, until something
+	// sends it.
+	EventApplicationSubmitted = "application.submitted"
+	// EventPing is only sent by the test endpoint and needs no subscription.
+	EventPing = "ping"
+)
+
+// EventTypes are the events a webhook can subscribe to.
+var EventTypes = []string{EventAnimalCreated, EventAnimalLiked, EventStatusChanged}
+
+const (
+	SignatureHeader = "X-FindYourPet-Signature"
+	EventHeader     = "X-FindYourPet-Event"
+	DeliveryHeader  = "X-FindYourPet-Delivery"
+)
+
+func IsEventType(name string) bool {
+	for _, t := range EventTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature header value for the payload: the timestamp and
+// the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the secret.
+// Receivers should recompute it and reject stale timestamps.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(payload)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}